@@ -0,0 +1,76 @@
+package termfmt
+
+import "strings"
+
+// Alignment positions text horizontally within a wider area
+type Alignment int
+
+const (
+	// AlignLeft places text against the left edge
+	AlignLeft Alignment = iota
+	// AlignCenter centers text, leaving any odd cell on the right
+	AlignCenter
+	// AlignRight places text against the right edge
+	AlignRight
+	// AlignDecimal lines up numbers on their decimal point in table columns
+	// and behaves like AlignRight elsewhere
+	AlignDecimal
+)
+
+// VerticalAlignment positions lines within a taller area
+type VerticalAlignment int
+
+const (
+	// AlignTop places lines at the top
+	AlignTop VerticalAlignment = iota
+	// AlignMiddle centers lines, leaving any odd line at the bottom
+	AlignMiddle
+	// AlignBottom places lines at the bottom
+	AlignBottom
+)
+
+// alignText pads s with spaces to width cells. Text that is already wider is
+// returned unchanged.
+func alignText(s string, width int, align Alignment) string {
+	gap := width - StringWidth(s)
+	if gap <= 0 {
+		return s
+	}
+
+	switch align {
+	case AlignCenter:
+		left := gap / 2 //nolint:mnd // half of the gap
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	case AlignRight, AlignDecimal:
+		return strings.Repeat(" ", gap) + s
+	case AlignLeft:
+		return s + strings.Repeat(" ", gap)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
+
+// alignLines adds empty lines to reach height lines. Longer input is returned
+// unchanged.
+func alignLines(lines []string, height int, align VerticalAlignment) []string {
+	gap := height - len(lines)
+	if gap <= 0 {
+		return lines
+	}
+
+	var top int
+
+	switch align {
+	case AlignMiddle:
+		top = gap / 2 //nolint:mnd // half of the gap
+	case AlignBottom:
+		top = gap
+	case AlignTop:
+	}
+
+	out := make([]string, 0, height)
+	out = append(out, make([]string, top)...)
+	out = append(out, lines...)
+
+	return append(out, make([]string, gap-top)...)
+}
@@ -0,0 +1,39 @@
+package termfmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignText(t *testing.T) {
+	tests := []struct {
+		align Alignment
+		want  string
+	}{
+		{AlignLeft, "ab   "},
+		{AlignCenter, " ab  "},
+		{AlignRight, "   ab"},
+	}
+
+	for _, tt := range tests {
+		if got := alignText("ab", 5, tt.align); got != tt.want {
+			t.Errorf("alignText(%d) = %q, want %q", tt.align, got, tt.want)
+		}
+	}
+
+	if got := alignText("日本", 6, AlignRight); got != "  日本" {
+		t.Errorf("alignText() with wide runes = %q", got)
+	}
+}
+
+func TestAlignLines(t *testing.T) {
+	got := alignLines([]string{"a"}, 4, AlignMiddle)
+	if want := []string{"", "a", "", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("alignLines() = %q, want %q", got, want)
+	}
+
+	got = alignLines([]string{"a"}, 3, AlignBottom)
+	if want := []string{"", "", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("alignLines() = %q, want %q", got, want)
+	}
+}
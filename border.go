@@ -0,0 +1,137 @@
+package termfmt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BorderStyle is the set of characters used to draw box and table borders.
+// Every field must be a single cell wide. Custom rune sets can be built by
+// filling in the struct or by modifying a copy of a built-in style.
+type BorderStyle struct {
+	Top    string // Horizontal line along the top, also used for separators
+	Bottom string // Horizontal line along the bottom
+	Left   string // Vertical line on the left, also used between table columns
+	Right  string // Vertical line on the right
+
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+
+	MiddleLeft   string // Separator joint on the left edge, such as ├
+	MiddleRight  string // Separator joint on the right edge, such as ┤
+	Middle       string // Separator crossing a column divider, such as ┼
+	MiddleTop    string // Top edge joint above a column divider, such as ┬
+	MiddleBottom string // Bottom edge joint below a column divider, such as ┴
+}
+
+// LightBorder returns single light lines, the default for simple boxes and tables
+func LightBorder() BorderStyle {
+	return BorderStyle{
+		Top: "─", Bottom: "─", Left: "│", Right: "│",
+		TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+		MiddleLeft: "├", MiddleRight: "┤", Middle: "┼", MiddleTop: "┬", MiddleBottom: "┴",
+	}
+}
+
+// RoundedBorder returns light lines with rounded corners
+func RoundedBorder() BorderStyle {
+	b := LightBorder()
+	b.TopLeft, b.TopRight, b.BottomLeft, b.BottomRight = "╭", "╮", "╰", "╯"
+
+	return b
+}
+
+// HeavyBorder returns single heavy lines
+func HeavyBorder() BorderStyle {
+	return BorderStyle{
+		Top: "━", Bottom: "━", Left: "┃", Right: "┃",
+		TopLeft: "┏", TopRight: "┓", BottomLeft: "┗", BottomRight: "┛",
+		MiddleLeft: "┣", MiddleRight: "┫", Middle: "╋", MiddleTop: "┳", MiddleBottom: "┻",
+	}
+}
+
+// DoubleBorder returns double lines, the default for titled boxes
+func DoubleBorder() BorderStyle {
+	return BorderStyle{
+		Top: "═", Bottom: "═", Left: "║", Right: "║",
+		TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+		MiddleLeft: "╠", MiddleRight: "╣", Middle: "╬", MiddleTop: "╦", MiddleBottom: "╩",
+	}
+}
+
+// DashedBorder returns light dashed lines with light corners
+func DashedBorder() BorderStyle {
+	b := LightBorder()
+	b.Top, b.Bottom, b.Left, b.Right = "╌", "╌", "╎", "╎"
+
+	return b
+}
+
+// ASCIIBorder returns borders drawn with '-', '|' and '+' only
+func ASCIIBorder() BorderStyle {
+	return BorderStyle{
+		Top: "-", Bottom: "-", Left: "|", Right: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		MiddleLeft: "+", MiddleRight: "+", Middle: "+", MiddleTop: "+", MiddleBottom: "+",
+	}
+}
+
+// HiddenBorder returns borders drawn with spaces, keeping the layout of a
+// bordered component without visible lines
+func HiddenBorder() BorderStyle {
+	return BorderStyle{
+		Top: " ", Bottom: " ", Left: " ", Right: " ",
+		TopLeft: " ", TopRight: " ", BottomLeft: " ", BottomRight: " ",
+		MiddleLeft: " ", MiddleRight: " ", Middle: " ", MiddleTop: " ", MiddleBottom: " ",
+	}
+}
+
+// parts returns every character of the border
+func (b BorderStyle) parts() []string {
+	return []string{
+		b.Top, b.Bottom, b.Left, b.Right,
+		b.TopLeft, b.TopRight, b.BottomLeft, b.BottomRight,
+		b.MiddleLeft, b.MiddleRight, b.Middle, b.MiddleTop, b.MiddleBottom,
+	}
+}
+
+// isASCII reports whether the border can be drawn without Unicode support
+func (b BorderStyle) isASCII() bool {
+	for _, part := range b.parts() {
+		for _, r := range part {
+			if r > unicode.MaxASCII {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// borderLine draws a horizontal border line with width fill cells between
+// the left and right characters
+func borderLine(left, fill, right string, width int) string {
+	return left + strings.Repeat(fill, width) + right
+}
+
+// borderOf returns the border configured in opts, or fallback when none is
+// set. Borders that need Unicode are replaced by ASCIIBorder when the
+// terminal does not support it.
+func borderOf(opts *TerminalOptions, fallback func() BorderStyle) BorderStyle {
+	border := fallback()
+	if opts == nil {
+		return border
+	}
+
+	if opts.Border != nil {
+		border = *opts.Border
+	}
+
+	if !opts.capabilities().Unicode && !border.isASCII() {
+		return ASCIIBorder()
+	}
+
+	return border
+}
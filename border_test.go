@@ -0,0 +1,72 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBoxBorderStyles(t *testing.T) {
+	tests := []struct {
+		name   string
+		border BorderStyle
+		corner string
+	}{
+		{"rounded", RoundedBorder(), "╭"},
+		{"heavy", HeavyBorder(), "┏"},
+		{"double", DoubleBorder(), "╔"},
+		{"dashed", DashedBorder(), "┌"},
+		{"ascii", ASCIIBorder(), "+"},
+		{"hidden", HiddenBorder(), " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := colorOptions()
+			opts.Border = &tt.border
+
+			box := BoxWithOptions("", "hello", opts)
+			if !strings.HasPrefix(box, tt.corner) {
+				t.Errorf("box does not start with %q:\n%s", tt.corner, box)
+			}
+
+			assertUniformWidth(t, tt.name, box)
+		})
+	}
+}
+
+func TestTableCustomBorder(t *testing.T) {
+	border := LightBorder()
+	border.Left, border.Right, border.Middle = "!", "!", "*"
+
+	opts := colorOptions()
+	opts.Border = &border
+
+	table := TableWithOptions([]string{"A", "B"}, [][]string{{"1", "2"}}, opts)
+	if !contains(table, "! A ! B !") || !contains(table, "├───*───┤") {
+		t.Errorf("table does not use the custom border:\n%s", table)
+	}
+}
+
+func TestBorderASCIIFallback(t *testing.T) {
+	opts := colorOptions()
+	opts.Capabilities = &Capabilities{}
+
+	box := BoxWithOptions("Title", "content", opts)
+	table := TableWithOptions([]string{"A"}, [][]string{{"1"}}, opts)
+
+	for _, output := range []string{box, table} {
+		for _, r := range output {
+			if r > 127 {
+				t.Errorf("output contains non-ASCII %q without Unicode support:\n%s", r, output)
+				break
+			}
+		}
+	}
+
+	custom := BorderStyle{Top: "=", Bottom: "=", Left: ":", Right: ":", TopLeft: "<", TopRight: ">", BottomLeft: "<", BottomRight: ">"}
+	opts.Border = &custom
+
+	if box := BoxWithOptions("", "x", opts); !strings.HasPrefix(box, "<===>") {
+		t.Errorf("ASCII custom border was replaced:\n%s", box)
+	}
+}
@@ -0,0 +1,377 @@
+package termfmt
+
+import "strings"
+
+const (
+	// borderCells is the number of border cells on each line of a box
+	borderCells = 2
+	// titleGap is the number of fill cells kept between a border title and the corners
+	titleGap = 1
+)
+
+// Spacing holds a number of cells or lines for each side of a box
+type Spacing struct {
+	Top, Right, Bottom, Left int
+}
+
+// UniformSpacing returns the same spacing on all four sides
+func UniformSpacing(n int) Spacing {
+	return Spacing{Top: n, Right: n, Bottom: n, Left: n}
+}
+
+// SymmetricSpacing returns vertical spacing above and below and horizontal
+// spacing on the left and right
+func SymmetricSpacing(vertical, horizontal int) Spacing {
+	return Spacing{Top: vertical, Right: horizontal, Bottom: vertical, Left: horizontal}
+}
+
+// clamped returns s with negative sides raised to 0
+func (s Spacing) clamped() Spacing {
+	return Spacing{Top: max(s.Top, 0), Right: max(s.Right, 0), Bottom: max(s.Bottom, 0), Left: max(s.Left, 0)}
+}
+
+// Box creates a bordered box around content with an optional title
+func Box(title, content string) string {
+	return BoxWithOptions(title, content, defaultOptions())
+}
+
+// BoxWithOptions creates a bordered box with custom options.
+// Content is wrapped so that the box fits within opts.Width. The border is
+// opts.Border, or LightBorder for untitled and DoubleBorder for titled boxes.
+// Use RenderBox for control over padding, size and alignment.
+func BoxWithOptions(title, content string, opts *TerminalOptions) string {
+	box := DefaultBoxOptions()
+	box.TitleRow = true
+
+	return RenderBox(title, content, box, opts)
+}
+
+// BoxOptions controls the layout of a box rendered by RenderBox.
+// Width and Height include the border and padding but not the margin.
+type BoxOptions struct {
+	Padding Spacing // Space between the border and the content
+	Margin  Spacing // Space around the border
+
+	Width     int // Fixed width in cells, 0 to fit the content
+	Height    int // Fixed height in lines, 0 to fit the content
+	MaxWidth  int // Upper bound on the width, 0 for opts.Width
+	MaxHeight int // Upper bound on the height, 0 for unbounded
+
+	Align         Alignment         // Horizontal alignment of content lines
+	VerticalAlign VerticalAlignment // Vertical alignment of content in a fixed height
+	TitleAlign    Alignment         // Position of the title
+
+	// TitleRow draws the title on its own row between the top border and the
+	// content, as Box does, instead of inside the top border line
+	TitleRow bool
+
+	Border *BorderStyle // Border for this box, nil for opts.Border
+}
+
+// DefaultBoxOptions returns the layout used by Box: one cell of horizontal
+// padding and a box sized to its content
+func DefaultBoxOptions() *BoxOptions {
+	return &BoxOptions{Padding: SymmetricSpacing(0, 1)}
+}
+
+// RenderBox draws a bordered box around content using the layout in box.
+// Content is wrapped to the available width and cut to the available height.
+// A nil box uses DefaultBoxOptions with the title inside the top border.
+// Negative padding and margins count as 0.
+//
+//	panel := termfmt.RenderBox("Status", "All systems go", &termfmt.BoxOptions{
+//		Padding:    termfmt.SymmetricSpacing(1, 2),
+//		Width:      40,
+//		Align:      termfmt.AlignCenter,
+//		TitleAlign: termfmt.AlignCenter,
+//	}, opts)
+func RenderBox(title, content string, box *BoxOptions, opts *TerminalOptions) string {
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	if box == nil {
+		box = DefaultBoxOptions()
+	}
+
+	clamped := *box
+	clamped.Padding, clamped.Margin = box.Padding.clamped(), box.Margin.clamped()
+	box = &clamped
+
+	if box.Border != nil {
+		withBorder := *opts
+		withBorder.Border = box.Border
+		opts = &withBorder
+	}
+
+	fallback := LightBorder
+	if title != "" && box.TitleRow {
+		fallback = DoubleBorder
+	}
+
+	l := newBoxLayout(title, content, box, opts)
+	if l == nil {
+		return ""
+	}
+
+	return l.render(borderOf(opts, fallback), opts)
+}
+
+// availableWidth returns the cells left in opts.Width after reserving chrome
+// cells, or 0 when the width is unbounded
+func availableWidth(opts *TerminalOptions, chrome int) int {
+	if opts.Width <= 0 {
+		return 0
+	}
+
+	return max(opts.Width-chrome, 1)
+}
+
+// boxLayout holds the measured lines and sizes of a box
+type boxLayout struct {
+	box   *BoxOptions
+	title string
+	lines []string
+	inner int // Width of the content area, without padding
+}
+
+// newBoxLayout wraps and sizes content for box, or returns nil when there is
+// nothing to draw
+func newBoxLayout(title, content string, box *BoxOptions, opts *TerminalOptions) *boxLayout {
+	chrome := borderCells + box.Padding.Left + box.Padding.Right
+	innerMax := boxInnerLimit(box, opts, chrome)
+
+	lines := wrapLines(content, innerMax)
+	if len(lines) == 0 && title == "" && box.Height <= 0 {
+		return nil
+	}
+
+	inner := 0
+	for _, line := range lines {
+		inner = max(inner, StringWidth(line))
+	}
+
+	if title != "" {
+		// A title row needs one cell on each side, a border title needs the
+		// spaces around it plus a fill cell at each end minus the padding
+		need := StringWidth(title) + borderCells
+		if !box.TitleRow {
+			need += borderCells*titleGap - box.Padding.Left - box.Padding.Right
+		}
+
+		if innerMax > 0 {
+			need = min(need, innerMax)
+		}
+
+		inner = max(inner, need)
+	}
+
+	if box.Width > 0 {
+		inner = max(box.Width-chrome, 0)
+	}
+
+	l := &boxLayout{box: box, title: title, lines: lines, inner: inner}
+	l.fitHeight()
+
+	return l
+}
+
+// boxInnerLimit returns the widest content line that fits the box limits,
+// or 0 when the width is unbounded
+func boxInnerLimit(box *BoxOptions, opts *TerminalOptions, chrome int) int {
+	limit := 0
+
+	for _, w := range []int{box.Width, box.MaxWidth, availableWidth(opts, box.Margin.Left+box.Margin.Right)} {
+		if w > 0 && (limit == 0 || w < limit) {
+			limit = w
+		}
+	}
+
+	if limit == 0 {
+		return 0
+	}
+
+	return max(limit-chrome, 1)
+}
+
+// fitHeight cuts or pads the content lines to the fixed or maximum height
+func (l *boxLayout) fitHeight() {
+	box := l.box
+
+	chrome := borderCells + box.Padding.Top + box.Padding.Bottom
+	if l.title != "" && box.TitleRow {
+		chrome += 2 //nolint:mnd // title row and its separator
+	}
+
+	if box.MaxHeight > 0 && len(l.lines)+chrome > box.MaxHeight {
+		l.lines = l.lines[:max(box.MaxHeight-chrome, 0)]
+	}
+
+	if box.Height > 0 {
+		rows := max(box.Height-chrome, 0)
+		if len(l.lines) > rows {
+			l.lines = l.lines[:rows]
+		}
+
+		l.lines = alignLines(l.lines, rows, box.VerticalAlign)
+	}
+}
+
+// render draws the box with border characters bs
+func (l *boxLayout) render(bs BorderStyle, opts *TerminalOptions) string {
+	box := l.box
+	theme := themeOf(opts)
+	border := theme.BoxBorder
+	fill := l.inner + box.Padding.Left + box.Padding.Right
+
+	margin := strings.Repeat(" ", box.Margin.Left)
+	marginRight := strings.Repeat(" ", box.Margin.Right)
+	left := border.RenderWithOptions(bs.Left, opts)
+	right := border.RenderWithOptions(bs.Right, opts)
+
+	var rows []string
+
+	row := func(text string, align Alignment) {
+		rows = append(rows, margin+left+
+			strings.Repeat(" ", box.Padding.Left)+alignText(text, l.inner, align)+strings.Repeat(" ", box.Padding.Right)+
+			right+marginRight)
+	}
+
+	edge := func(line string) {
+		rows = append(rows, margin+line+marginRight)
+	}
+
+	if l.title != "" && !box.TitleRow {
+		edge(l.titleLine(bs, fill, opts))
+	} else {
+		edge(border.RenderWithOptions(borderLine(bs.TopLeft, bs.Top, bs.TopRight, fill), opts))
+	}
+
+	if l.title != "" && box.TitleRow {
+		width := max(fill-borderPadding, 1)
+		title := theme.BoxTitle.RenderWithOptions(Truncate(l.title, width), opts)
+		rows = append(rows, margin+left+" "+alignText(title, width, box.TitleAlign)+" "+right+marginRight)
+		edge(border.RenderWithOptions(borderLine(bs.MiddleLeft, bs.Top, bs.MiddleRight, fill), opts))
+	}
+
+	for range box.Padding.Top {
+		row("", AlignLeft)
+	}
+
+	for _, line := range l.lines {
+		row(Truncate(line, l.inner), box.Align)
+	}
+
+	for range box.Padding.Bottom {
+		row("", AlignLeft)
+	}
+
+	edge(border.RenderWithOptions(borderLine(bs.BottomLeft, bs.Bottom, bs.BottomRight, fill), opts))
+
+	return addVerticalMargin(rows, box.Margin, StringWidth(rows[0]))
+}
+
+// titleLine draws the top border with the title embedded at box.TitleAlign
+func (l *boxLayout) titleLine(bs BorderStyle, fill int, opts *TerminalOptions) string {
+	theme := themeOf(opts)
+	border := theme.BoxBorder
+
+	room := fill - borderCells*titleGap - borderPadding // Fill cells and spaces around the title
+	if room <= 0 {
+		// Too narrow for the gaps, so the title takes the whole border
+		title := Truncate(l.title, fill)
+
+		return border.RenderWithOptions(bs.TopLeft, opts) +
+			theme.BoxTitle.RenderWithOptions(title, opts) +
+			border.RenderWithOptions(strings.Repeat(bs.Top, fill-StringWidth(title))+bs.TopRight, opts)
+	}
+
+	title := Truncate(l.title, room)
+	gap := fill - StringWidth(title) - borderPadding
+
+	var before int
+
+	switch l.box.TitleAlign {
+	case AlignCenter:
+		before = gap / 2 //nolint:mnd // half of the gap
+	case AlignRight, AlignDecimal:
+		before = gap - titleGap
+	case AlignLeft:
+		before = titleGap
+	}
+
+	return border.RenderWithOptions(bs.TopLeft+strings.Repeat(bs.Top, before)+" ", opts) +
+		theme.BoxTitle.RenderWithOptions(title, opts) +
+		border.RenderWithOptions(" "+strings.Repeat(bs.Top, gap-before)+bs.TopRight, opts)
+}
+
+// addVerticalMargin joins rows, surrounding them with blank lines of width cells
+func addVerticalMargin(rows []string, margin Spacing, width int) string {
+	blank := strings.Repeat(" ", width)
+
+	out := make([]string, 0, len(rows)+margin.Top+margin.Bottom)
+	for range margin.Top {
+		out = append(out, blank)
+	}
+
+	out = append(out, rows...)
+	for range margin.Bottom {
+		out = append(out, blank)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// ErrorBox draws a box whose border and title use the theme's error color,
+// with the title prefixed by the error icon
+func ErrorBox(title, content string, opts *TerminalOptions) string {
+	return semanticBox("error", title, content, opts)
+}
+
+// WarningBox draws a box whose border and title use the theme's warning color,
+// with the title prefixed by the warning icon
+func WarningBox(title, content string, opts *TerminalOptions) string {
+	return semanticBox("warning", title, content, opts)
+}
+
+// InfoBox draws a box whose border and title use the theme's info color,
+// with the title prefixed by the info icon
+func InfoBox(title, content string, opts *TerminalOptions) string {
+	return semanticBox("info", title, content, opts)
+}
+
+// SuccessBox draws a box whose border and title use the theme's success color,
+// with the title prefixed by the success icon
+func SuccessBox(title, content string, opts *TerminalOptions) string {
+	return semanticBox("success", title, content, opts)
+}
+
+// semanticBox draws a box colored with the theme color for kind. The icon
+// comes from GetEmoji, or is the text label in accessibility mode, so that
+// the kind of the box never depends on color alone.
+func semanticBox(kind, title, content string, opts *TerminalOptions) string {
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	theme := themeOf(opts).Clone()
+	color := Color(*theme.colorFields()[kind])
+	theme.BoxBorder = NewStyle().Foreground(color)
+	theme.BoxTitle = NewStyle().Bold(true).Foreground(color)
+
+	styled := *opts
+	styled.Theme = theme
+
+	icon := GetEmoji(kind, opts)
+	if opts.Accessible {
+		icon = getEmojiMap()[kind][1]
+	}
+
+	if title == "" {
+		title = icon
+	} else {
+		title = icon + " " + title
+	}
+
+	return RenderBox(title, content, nil, &styled)
+}
@@ -0,0 +1,147 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderBoxFixedSize(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := RenderBox("Status", "ok", &BoxOptions{
+		Padding:       SymmetricSpacing(0, 1),
+		Width:         20,
+		Height:        5,
+		VerticalAlign: AlignMiddle,
+		Align:         AlignCenter,
+	}, opts)
+
+	lines := strings.Split(box, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("RenderBox() has %d lines, want 5:\n%s", len(lines), box)
+	}
+
+	assertUniformWidth(t, "RenderBox", box)
+
+	if StringWidth(lines[0]) != 20 {
+		t.Errorf("RenderBox() width = %d, want 20", StringWidth(lines[0]))
+	}
+
+	if lines[2] != "│        ok        │" {
+		t.Errorf("content not centered: %q", lines[2])
+	}
+}
+
+func TestRenderBoxPaddingAndMargin(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := RenderBox("", "x", &BoxOptions{
+		Padding: UniformSpacing(1),
+		Margin:  Spacing{Top: 1, Left: 2},
+	}, opts)
+
+	want := "       \n  ┌───┐\n  │   │\n  │ x │\n  │   │\n  └───┘"
+	if box != want {
+		t.Errorf("RenderBox() = %q, want %q", box, want)
+	}
+}
+
+func TestRenderBoxNegativeSpacing(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := RenderBox("", "x", &BoxOptions{Padding: UniformSpacing(-1), Margin: Spacing{Top: -2, Left: -1}}, opts)
+
+	want := "┌─┐\n│x│\n└─┘"
+	if box != want {
+		t.Errorf("RenderBox() with negative spacing = %q, want %q", box, want)
+	}
+}
+
+func TestRenderBoxTitleAlignment(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	tests := []struct {
+		align Alignment
+		want  string
+	}{
+		{AlignLeft, "┌─ Title ──────────┐"},
+		{AlignCenter, "┌───── Title ──────┐"},
+		{AlignRight, "┌────────── Title ─┐"},
+	}
+
+	for _, tt := range tests {
+		box := RenderBox("Title", "content", &BoxOptions{Padding: SymmetricSpacing(0, 1), Width: 20, TitleAlign: tt.align}, opts)
+		if top := strings.Split(box, "\n")[0]; top != tt.want {
+			t.Errorf("title line = %q, want %q", top, tt.want)
+		}
+	}
+}
+
+func TestRenderBoxNarrowTitle(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := RenderBox("Status", "ok", &BoxOptions{Width: 6}, opts)
+	if top := strings.Split(box, "\n")[0]; top != "┌S...┐" {
+		t.Errorf("title line = %q, want the truncated title", top)
+	}
+
+	assertUniformWidth(t, "RenderBox", box)
+}
+
+func TestRenderBoxMaxHeight(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := RenderBox("", "1\n2\n3\n4\n5", &BoxOptions{MaxHeight: 4}, opts)
+	if lines := strings.Split(box, "\n"); len(lines) != 4 || contains(box, "3") {
+		t.Errorf("RenderBox() did not cut content to MaxHeight:\n%s", box)
+	}
+}
+
+func TestSemanticBoxes(t *testing.T) {
+	opts := colorOptions()
+	opts.Emoji = false
+
+	tests := []struct {
+		name  string
+		box   func(string, string, *TerminalOptions) string
+		label string
+		color string
+	}{
+		{"error", ErrorBox, "[ERR]", DefaultTheme().Error},
+		{"warning", WarningBox, "[WRN]", DefaultTheme().Warning},
+		{"info", InfoBox, "[INF]", DefaultTheme().Info},
+		{"success", SuccessBox, "[OK]", DefaultTheme().Success},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := tt.box("Deploy", "details", opts)
+			if !contains(box, tt.label+" Deploy") {
+				t.Errorf("box title is not prefixed with %s:\n%s", tt.label, box)
+			}
+
+			if !contains(box, Color(tt.color).Sequence(ColorLevel16)+"┌") {
+				t.Errorf("box border does not use the %s color: %q", tt.name, box)
+			}
+
+			assertUniformWidth(t, tt.name, box)
+		})
+	}
+}
+
+func TestSemanticBoxAccessible(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Accessible = true
+	opts.Capabilities = &Capabilities{Unicode: true, Emoji: true}
+
+	if box := ErrorBox("Failed", "", opts); !contains(box, "[ERR] Failed") {
+		t.Errorf("ErrorBox() in accessibility mode has no text label:\n%s", box)
+	}
+}
@@ -1,7 +1,6 @@
 package termfmt
 
 import (
-	"os"
 	"strings"
 )
 
@@ -80,7 +79,8 @@ func getEmojiMap() map[string][2]string {
 	}
 }
 
-// ColorProfile represents a color scheme
+// ColorProfile represents a color scheme. Fields accept the SGR constants as
+// well as color names, palette indexes and hex values, see Color.
 type ColorProfile struct {
 	Error   string
 	Warning string
@@ -114,13 +114,96 @@ func HighContrastColorProfile() *ColorProfile {
 	}
 }
 
-// Colorize applies color to text if color is enabled
+// DeuteranopiaColorProfile returns a color scheme that stays distinguishable
+// with reduced green sensitivity, contrasting vermillion with blue
+func DeuteranopiaColorProfile() *ColorProfile {
+	return &ColorProfile{
+		Error:   "#d55e00",
+		Warning: "#f0e442",
+		Info:    "#56b4e9",
+		Success: "#005ab5",
+		Accent:  "#cc79a7",
+		Muted:   "#999999",
+	}
+}
+
+// ProtanopiaColorProfile returns a color scheme that stays distinguishable
+// with reduced red sensitivity, avoiding dark reds that appear black
+func ProtanopiaColorProfile() *ColorProfile {
+	return &ColorProfile{
+		Error:   "#e69f00",
+		Warning: "#f0e442",
+		Info:    "#56b4e9",
+		Success: "#005ab5",
+		Accent:  "#cc79a7",
+		Muted:   "#999999",
+	}
+}
+
+// TritanopiaColorProfile returns a color scheme that stays distinguishable
+// with reduced blue sensitivity, contrasting red and magenta with blue and teal
+func TritanopiaColorProfile() *ColorProfile {
+	return &ColorProfile{
+		Error:   "#cc3311",
+		Warning: "#ee3377",
+		Info:    "#3366cc",
+		Success: "#009988",
+		Accent:  "#ee7733",
+		Muted:   "#bbbbbb",
+	}
+}
+
+// Colorize applies color to text if color is enabled. color may be any value
+// accepted by Color and is downsampled to the terminal's color level.
 func Colorize(text, color string, opts *TerminalOptions) string {
-	if !opts.Color || !supportsColor() {
+	return colorizeAt(text, Color(color), activeColorLevel(opts))
+}
+
+// colorizeAt wraps text in the sequence for color at the given level
+func colorizeAt(text string, color Color, level ColorLevel) string {
+	seq := color.Sequence(level)
+	if seq == "" {
 		return text
 	}
 
-	return color + text + Reset
+	return seq + text + Reset
+}
+
+// activeColorLevel returns the color level to render at, or ColorLevelNone
+// when color output is disabled. An explicit ColorMode wins over Color.
+func activeColorLevel(opts *TerminalOptions) ColorLevel {
+	switch opts.ColorMode {
+	case ColorNever:
+		return ColorLevelNone
+	case ColorAlways:
+		return max(opts.capabilities().ColorLevel, ColorLevel16)
+	case ColorAuto:
+	}
+
+	if !opts.Color {
+		return ColorLevelNone
+	}
+
+	return opts.capabilities().ColorLevel
+}
+
+// emojiEnabled reports whether emoji are both requested and supported
+func emojiEnabled(opts *TerminalOptions) bool {
+	return opts.Emoji && opts.capabilities().Emoji
+}
+
+// Hyperlink returns text linked to url using an OSC 8 escape sequence when
+// the terminal supports hyperlinks, and "text (url)" otherwise
+func Hyperlink(text, url string, opts *TerminalOptions) string {
+	if opts.capabilities().Hyperlinks {
+		return "\033]8;;" + url + "\033\\" + text + hyperlinkClose
+	}
+
+	if text == "" || text == url {
+		return url
+	}
+
+	return text + " (" + url + ")"
 }
 
 // ColorizeWithProfile applies color using a color profile
@@ -129,7 +212,8 @@ func ColorizeWithProfile(
 	profile *ColorProfile,
 	opts *TerminalOptions,
 ) string {
-	if !opts.Color || !supportsColor() {
+	level := activeColorLevel(opts)
+	if level == ColorLevelNone {
 		return text
 	}
 
@@ -152,7 +236,7 @@ func ColorizeWithProfile(
 		return text
 	}
 
-	return color + text + Reset
+	return colorizeAt(text, Color(color), level)
 }
 
 // GetEmoji returns emoji or fallback based on options
@@ -164,7 +248,7 @@ func GetEmoji(key string, opts *TerminalOptions) string {
 		}
 
 		// If user wants emoji but terminal doesn't support it, graceful fallback
-		if !supportsEmoji() {
+		if !opts.capabilities().Emoji {
 			return mapping[1] // fallback
 		}
 
@@ -184,7 +268,7 @@ func CreateConfidenceBar(confidence float64, opts *TerminalOptions) string {
 	barLength := int(confidence * ConfidenceBarLength)
 
 	var filled, empty rune
-	if opts.Emoji && supportsEmoji() {
+	if emojiEnabled(opts) {
 		filled = '█'
 		empty = '░'
 	} else {
@@ -192,18 +276,16 @@ func CreateConfidenceBar(confidence float64, opts *TerminalOptions) string {
 		empty = '-'
 	}
 
+	barLength = min(max(barLength, 0), ConfidenceBarLength)
+	theme := themeOf(opts)
+
 	var b strings.Builder
 	if !opts.Emoji {
 		b.WriteString("[")
 	}
 
-	for i := range ConfidenceBarLength {
-		if i < barLength {
-			b.WriteRune(filled)
-		} else {
-			b.WriteRune(empty)
-		}
-	}
+	b.WriteString(theme.BarFill.RenderWithOptions(strings.Repeat(string(filled), barLength), opts))
+	b.WriteString(theme.BarEmpty.RenderWithOptions(strings.Repeat(string(empty), ConfidenceBarLength-barLength), opts))
 
 	if !opts.Emoji {
 		b.WriteString("]")
@@ -212,157 +294,56 @@ func CreateConfidenceBar(confidence float64, opts *TerminalOptions) string {
 	return b.String()
 }
 
-// supportsColor checks if the terminal supports color output
-func supportsColor() bool {
-	// Check common environment variables
-	term := os.Getenv("TERM")
-	if term == "" {
-		return false
-	}
-
-	// Check for known color-supporting terminals
-	colorTerms := []string{
-		"xterm", "xterm-256color", "xterm-color",
-		"screen", "screen-256color",
-		"tmux", "tmux-256color",
-		"rxvt", "rxvt-unicode",
-		"linux", "ansi",
-	}
-
-	for _, colorTerm := range colorTerms {
-		if strings.Contains(term, colorTerm) {
-			return true
-		}
-	}
-
-	// Check for NO_COLOR environment variable
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-
-	// Check for FORCE_COLOR environment variable
-	if os.Getenv("FORCE_COLOR") != "" {
-		return true
-	}
-
-	return false
-}
-
-// supportsEmoji checks if the terminal likely supports emoji
-func supportsEmoji() bool {
-	// Similar to color support, but more conservative
-	term := os.Getenv("TERM")
-	if strings.Contains(term, "256color") {
-		return true
-	}
-
-	// Check for modern terminals
-	if os.Getenv("TERM_PROGRAM") != "" {
-		return true
-	}
-
-	return false
-}
-
-// Stylize applies multiple styles to text
+// Stylize applies multiple styles to text. Names are those accepted by
+// ParseStyle; unknown names are ignored. Use Style for new code.
 func Stylize(text string, styles []string, opts *TerminalOptions) string {
-	if !opts.Color || !supportsColor() {
-		return text
-	}
-
-	var codes []string
-
-	for _, style := range styles {
-		switch style {
-		case "bold":
-			codes = append(codes, Bold)
-		case "dim":
-			codes = append(codes, Dim)
-		case "italic":
-			codes = append(codes, Italic)
-		case "red":
-			codes = append(codes, Red)
-		case "green":
-			codes = append(codes, Green)
-		case "yellow":
-			codes = append(codes, Yellow)
-		case "blue":
-			codes = append(codes, Blue)
-		case "magenta":
-			codes = append(codes, Magenta)
-		case "cyan":
-			codes = append(codes, Cyan)
-		case "white":
-			codes = append(codes, White)
-		}
-	}
+	style, _ := parseStyleFields(styles) //nolint:errcheck // unknown names are ignored for compatibility
 
-	if len(codes) == 0 {
-		return text
-	}
-
-	return strings.Join(codes, "") + text + Reset
+	return style.RenderWithOptions(text, opts)
 }
 
-// Header creates a styled header with optional color
+// Header creates a styled header using the theme's Header style
 func Header(title string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(title, []string{"bold", "cyan"}, opts)
-	}
-
-	return title
+	return themeOf(opts).Header.RenderWithOptions(title, opts)
 }
 
-// Subtitle creates a styled subtitle
+// Subtitle creates a styled subtitle using the theme's Subtitle style
 func Subtitle(title string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(title, []string{"bold"}, opts)
-	}
-
-	return title
+	return themeOf(opts).Subtitle.RenderWithOptions(title, opts)
 }
 
-// Muted creates muted/dimmed text
+// Muted creates muted/dimmed text using the theme's MutedText style
 func Muted(text string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(text, []string{"dim"}, opts)
-	}
-
-	return text
+	return themeOf(opts).MutedText.RenderWithOptions(text, opts)
 }
 
 // Success creates success-styled text
 func Success(text string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(text, []string{"green"}, opts)
-	}
-
-	return text
+	return semanticText(text, "success", opts)
 }
 
 // Warning creates warning-styled text
 func Warning(text string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(text, []string{"yellow"}, opts)
-	}
-
-	return text
+	return semanticText(text, "warning", opts)
 }
 
 // Error creates error-styled text
 func Error(text string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(text, []string{"red"}, opts)
-	}
-
-	return text
+	return semanticText(text, "error", opts)
 }
 
 // Info creates info-styled text
 func Info(text string, opts *TerminalOptions) string {
-	if opts.Color && supportsColor() {
-		return Stylize(text, []string{"blue"}, opts)
+	return semanticText(text, "info", opts)
+}
+
+// semanticText colors text with the theme color for kind. In accessibility
+// mode the text fallback symbol for kind is prefixed, so that the meaning
+// does not depend on color alone.
+func semanticText(text, kind string, opts *TerminalOptions) string {
+	if opts.Accessible {
+		text = getEmojiMap()[kind][1] + " " + text
 	}
 
-	return text
+	return ColorizeWithProfile(text, kind, &themeOf(opts).ColorProfile, opts)
 }
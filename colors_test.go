@@ -0,0 +1,76 @@
+package termfmt
+
+import (
+	"testing"
+)
+
+func TestAccessibleModeAddsTextLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(string, *TerminalOptions) string
+		want   string
+	}{
+		{"error", Error, "[ERR] disk full"},
+		{"warning", Warning, "[WRN] disk full"},
+		{"info", Info, "[INF] disk full"},
+		{"success", Success, "[OK] disk full"},
+	}
+
+	opts := colorOptions()
+	opts.Color = false
+	opts.Accessible = true
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.render("disk full", opts); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorizeWithProfileDownsamples(t *testing.T) {
+	brand := "#ff8800"
+	profile := ColorProfile{Accent: brand}
+
+	opts := colorOptions()
+	opts.Capabilities = &Capabilities{ColorLevel: ColorLevel256}
+
+	if got, want := ColorizeWithProfile("x", "accent", &profile, opts), "\x1b[38;5;208mx"+Reset; got != want {
+		t.Errorf("ColorizeWithProfile() = %q, want %q", got, want)
+	}
+}
+
+func TestColorBlindProfilesKeepSemanticColorsApart(t *testing.T) {
+	profiles := map[string]*ColorProfile{
+		"deuteranopia": DeuteranopiaColorProfile(),
+		"protanopia":   ProtanopiaColorProfile(),
+		"tritanopia":   TritanopiaColorProfile(),
+	}
+
+	for name, profile := range profiles {
+		colors := map[string]string{
+			"error":   profile.Error,
+			"warning": profile.Warning,
+			"info":    profile.Info,
+			"success": profile.Success,
+		}
+
+		for _, level := range []ColorLevel{ColorLevel16, ColorLevel256, ColorLevelTrueColor} {
+			seen := make(map[string]string, len(colors))
+
+			for _, kind := range []string{"error", "warning", "info", "success"} {
+				seq := Color(colors[kind]).Sequence(level)
+				if other, ok := seen[seq]; ok {
+					t.Errorf("%s: %s and %s share %q at level %s", name, other, kind, seq, level)
+				}
+
+				seen[seq] = kind
+			}
+		}
+
+		if _, err := ThemeByName(name); err != nil {
+			t.Errorf("ThemeByName(%q) error = %v", name, err)
+		}
+	}
+}
@@ -12,207 +12,69 @@ const (
 	progressSpacing   = 20  // Space reserved for progress bar metadata
 	tableRowPadding   = 2   // Extra padding for table rows
 	percentMultiplier = 100 // Multiplier for percentage calculations
+	minColumnWidth    = 3   // Narrowest width a table column or value is shrunk to
 )
 
-// Box creates a bordered box around content with an optional title
-func Box(title, content string) string {
-	return BoxWithOptions(title, content, DefaultOptions())
-}
-
-// BoxWithOptions creates a bordered box with custom options
-func BoxWithOptions(title, content string, opts *TerminalOptions) string {
-	if title == "" {
-		return simpleBox(content)
-	}
-
-	return titledBox(title, content)
-}
-
-// simpleBox creates a simple bordered box
-func simpleBox(content string) string {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 {
-		return ""
-	}
-
-	// Find the maximum line length
-	maxLen := 0
-	for _, line := range lines {
-		if len(line) > maxLen {
-			maxLen = len(line)
-		}
-	}
-
-	var b strings.Builder
-
-	// Top border
-	b.WriteString("┌" + strings.Repeat("─", maxLen+borderPadding) + "┐\n")
-
-	// Content lines
-	for _, line := range lines {
-		padding := maxLen - len(line)
-		b.WriteString("│ " + line + strings.Repeat(" ", padding) + " │\n")
-	}
-
-	// Bottom border
-	b.WriteString("└" + strings.Repeat("─", maxLen+borderPadding) + "┘")
-
-	return b.String()
-}
-
-// titledBox creates a box with a title
-func titledBox(title, content string) string {
-	titleLen := len(title)
-	lines := strings.Split(content, "\n")
-
-	// Find the maximum line length
-	maxLen := titleLen + borderPadding // Title + padding
-	for _, line := range lines {
-		if len(line) > maxLen {
-			maxLen = len(line)
-		}
-	}
-
-	var b strings.Builder
-
-	// Top border with title
-	b.WriteString("╔" + strings.Repeat("═", maxLen+borderPadding) + "╗\n")
-	titlePadding := maxLen - titleLen
-	b.WriteString("║ " + title + strings.Repeat(" ", titlePadding) + " ║\n")
-	b.WriteString("╠" + strings.Repeat("═", maxLen+borderPadding) + "╣\n")
-
-	// Content lines
-	for _, line := range lines {
-		padding := maxLen - len(line)
-		b.WriteString("║ " + line + strings.Repeat(" ", padding) + " ║\n")
-	}
-
-	// Bottom border
-	b.WriteString("╚" + strings.Repeat("═", maxLen+borderPadding) + "╝")
-
-	return b.String()
+// BarChart creates a horizontal bar chart from data
+func BarChart(data map[string]int, width int) string {
+	return BarChartWithOptions(data, width, defaultOptions())
 }
 
-// Table creates a formatted table from headers and rows
-func Table(headers []string, rows [][]string) string {
-	return TableWithOptions(headers, rows, DefaultOptions())
+// BarChartWithOptions creates a horizontal bar chart with custom options.
+// A width of 0 uses opts.Width.
+func BarChartWithOptions(data map[string]int, width int, opts *TerminalOptions) string {
+	return BarChartPairs(barEntries(data), width, opts)
 }
 
-// TableWithOptions creates a formatted table with custom options
-func TableWithOptions(headers []string, rows [][]string, opts *TerminalOptions) string {
-	if len(headers) == 0 {
+// BarChartPairs creates a bar chart from bars, ordered by opts.Ordering.
+// With the zero Ordering the bars keep the order given. Bars with negative
+// values are drawn empty.
+func BarChartPairs(bars []Bar, width int, opts *TerminalOptions) string {
+	if len(bars) == 0 {
 		return ""
 	}
 
-	// Calculate column widths
-	colWidths := make([]int, len(headers))
-	for i, header := range headers {
-		colWidths[i] = len(header)
-	}
-
-	for _, row := range rows {
-		for i, cell := range row {
-			if i < len(colWidths) && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
-			}
-		}
-	}
-
-	var b strings.Builder
-
-	// Header row
-	b.WriteString("│")
-
-	for i, header := range headers {
-		padding := colWidths[i] - len(header)
-		b.WriteString(" " + header + strings.Repeat(" ", padding) + " │")
-	}
-
-	b.WriteString("\n")
-
-	// Separator
-	b.WriteString("├")
-
-	for i, width := range colWidths {
-		b.WriteString(strings.Repeat("─", width+tableRowPadding))
-
-		if i < len(colWidths)-1 {
-			b.WriteString("┼")
-		}
+	if opts == nil {
+		opts = defaultOptions()
 	}
 
-	b.WriteString("┤\n")
-
-	// Data rows
-	for _, row := range rows {
-		b.WriteString("│")
-
-		for i, cell := range row {
-			if i < len(colWidths) {
-				padding := colWidths[i] - len(cell)
-				b.WriteString(" " + cell + strings.Repeat(" ", padding) + " │")
-			}
-		}
-
-		b.WriteString("\n")
+	if width == 0 {
+		width = opts.Width
 	}
 
-	return strings.TrimRight(b.String(), "\n")
-}
-
-// BarChart creates a horizontal bar chart from data
-func BarChart(data map[string]int, width int) string {
-	return BarChartWithOptions(data, width, DefaultOptions())
-}
-
-// BarChartWithOptions creates a horizontal bar chart with custom options
-func BarChartWithOptions(data map[string]int, width int, opts *TerminalOptions) string {
-	if len(data) == 0 {
-		return ""
-	}
+	bars = orderBars(bars, opts.Ordering)
 
-	// Find max value for scaling
-	maxValue := 0
+	// Find max value for scaling, at least 1 so that charts without a
+	// positive value still draw their bars empty
+	maxValue := 1
 	maxLabelLen := 0
 
-	for label, value := range data {
-		if value > maxValue {
-			maxValue = value
+	for _, bar := range bars {
+		if bar.Value > maxValue {
+			maxValue = bar.Value
 		}
 
-		if len(label) > maxLabelLen {
-			maxLabelLen = len(label)
-		}
+		maxLabelLen = max(maxLabelLen, StringWidth(bar.Label))
 	}
 
-	if maxValue == 0 {
-		return ""
-	}
+	theme := themeOf(opts)
 
 	var b strings.Builder
 
-	barWidth := width - maxLabelLen - labelSpacing // Leave space for label and value
+	barWidth := max(width-maxLabelLen-labelSpacing, 1) // Leave space for label and value
 
-	for label, value := range data {
+	for _, bar := range bars {
 		// Label (right-padded)
-		labelPadding := maxLabelLen - len(label)
-		b.WriteString(label + strings.Repeat(" ", labelPadding))
+		b.WriteString(padRight(bar.Label, maxLabelLen))
 
 		// Bar
-		barLength := int(float64(value) / float64(maxValue) * float64(barWidth))
+		barLength := max(int(float64(bar.Value)/float64(maxValue)*float64(barWidth)), 0)
 
 		b.WriteString(" │")
-
-		if opts.Emoji {
-			b.WriteString(strings.Repeat("█", barLength))
-			b.WriteString(strings.Repeat("░", barWidth-barLength))
-		} else {
-			b.WriteString(strings.Repeat("#", barLength))
-			b.WriteString(strings.Repeat("-", barWidth-barLength))
-		}
+		b.WriteString(renderBar(barLength, barWidth-barLength, theme.BarFill, theme.BarEmpty, opts))
 
 		// Value
-		b.WriteString(fmt.Sprintf("│ %d\n", value))
+		b.WriteString(fmt.Sprintf("│ %d\n", bar.Value))
 	}
 
 	return strings.TrimRight(b.String(), "\n")
@@ -220,7 +82,7 @@ func BarChartWithOptions(data map[string]int, width int, opts *TerminalOptions)
 
 // TreeView creates a tree-style view with prefix indicators
 func TreeView(items []TreeItem) string {
-	return TreeViewWithOptions(items, DefaultOptions())
+	return TreeViewWithOptions(items, defaultOptions())
 }
 
 // TreeItem represents an item in a tree view
@@ -231,7 +93,8 @@ type TreeItem struct {
 	Last     bool // Whether this is the last item in its group
 }
 
-// TreeViewWithOptions creates a tree-style view with custom options
+// TreeViewWithOptions creates a tree-style view with custom options.
+// Values are truncated so that each line fits within opts.Width.
 func TreeViewWithOptions(items []TreeItem, opts *TerminalOptions) string {
 	var b strings.Builder
 
@@ -241,7 +104,9 @@ func TreeViewWithOptions(items []TreeItem, opts *TerminalOptions) string {
 }
 
 // renderTreeItems recursively renders tree items
-func renderTreeItems(b *strings.Builder, items []TreeItem, prefix string, _ *TerminalOptions) {
+func renderTreeItems(b *strings.Builder, items []TreeItem, prefix string, opts *TerminalOptions) {
+	guide := themeOf(opts).TreeGuide
+
 	for i, item := range items {
 		isLast := i == len(items)-1
 
@@ -256,27 +121,38 @@ func renderTreeItems(b *strings.Builder, items []TreeItem, prefix string, _ *Ter
 		}
 
 		// Write the item
-		b.WriteString(prefix + itemPrefix + item.Label)
+		b.WriteString(guide.RenderWithOptions(prefix+itemPrefix, opts) + item.Label)
 
 		if item.Value != "" {
-			b.WriteString(": " + item.Value)
+			line := prefix + itemPrefix + item.Label + ": "
+			b.WriteString(": " + fitTreeValue(item.Value, StringWidth(line), opts))
 		}
 
 		b.WriteString("\n")
 
 		// Render children
 		if len(item.Children) > 0 {
-			renderTreeItems(b, item.Children, prefix+childPrefix, nil)
+			renderTreeItems(b, item.Children, prefix+childPrefix, opts)
 		}
 	}
 }
 
+// fitTreeValue truncates a tree value to the cells left after used on its line
+func fitTreeValue(value string, used int, opts *TerminalOptions) string {
+	if opts == nil || opts.Width <= 0 {
+		return value
+	}
+
+	return Truncate(value, max(opts.Width-used, minColumnWidth))
+}
+
 // ProgressBar creates a progress bar
 func ProgressBar(current, total, width int) string {
-	return ProgressBarWithOptions(current, total, width, DefaultOptions())
+	return ProgressBarWithOptions(current, total, width, defaultOptions())
 }
 
-// ProgressBarWithOptions creates a progress bar with custom options
+// ProgressBarWithOptions creates a progress bar with custom options. When
+// width leaves no room for the bar, the counts are left out.
 func ProgressBarWithOptions(current, total, width int, opts *TerminalOptions) string {
 	if total <= 0 {
 		return ""
@@ -287,22 +163,32 @@ func ProgressBarWithOptions(current, total, width int, opts *TerminalOptions) st
 		percentage = 1.0
 	}
 
-	barWidth := width - progressSpacing // Leave space for percentage and brackets
-	filledWidth := int(percentage * float64(barWidth))
+	barWidth := max(width-progressSpacing, 0) // Leave space for percentage and brackets
+	filledWidth := max(int(percentage*float64(barWidth)), 0)
 
 	var b strings.Builder
 
+	theme := themeOf(opts)
+
 	b.WriteString("[")
+	b.WriteString(renderBar(filledWidth, barWidth-filledWidth, theme.ProgressFill, theme.ProgressEmpty, opts))
 
-	if opts.Emoji {
-		b.WriteString(strings.Repeat("█", filledWidth))
-		b.WriteString(strings.Repeat("░", barWidth-filledWidth))
-	} else {
-		b.WriteString(strings.Repeat("#", filledWidth))
-		b.WriteString(strings.Repeat("-", barWidth-filledWidth))
-	}
+	b.WriteString(fmt.Sprintf("] %.1f%%", percentage*percentMultiplier))
 
-	b.WriteString(fmt.Sprintf("] %.1f%% (%d/%d)", percentage*percentMultiplier, current, total))
+	if barWidth > 0 {
+		b.WriteString(fmt.Sprintf(" (%d/%d)", current, total))
+	}
 
 	return b.String()
 }
+
+// renderBar draws the filled and empty parts of a bar with their theme styles
+func renderBar(filled, empty int, fillStyle, emptyStyle Style, opts *TerminalOptions) string {
+	fillRune, emptyRune := "#", "-"
+	if opts.Emoji {
+		fillRune, emptyRune = "█", "░"
+	}
+
+	return fillStyle.RenderWithOptions(strings.Repeat(fillRune, filled), opts) +
+		emptyStyle.RenderWithOptions(strings.Repeat(emptyRune, empty), opts)
+}
@@ -0,0 +1,66 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComponentsHonorWidth(t *testing.T) {
+	opts := colorOptions()
+	opts.Width = 30
+
+	long := "This sentence is much longer than the thirty cells we allow for the box."
+
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{"simple box", BoxWithOptions("", long, opts)},
+		{"titled box", BoxWithOptions("A rather long title for a box", long, opts)},
+		{"table", TableWithOptions(
+			[]string{"Service", "Description"},
+			[][]string{{"api", long}, {"worker-with-long-name", "short"}},
+			opts,
+		)},
+		{"tree", TreeViewWithOptions([]TreeItem{
+			{Label: "Root", Children: []TreeItem{{Label: "Child", Value: long}}},
+		}, opts)},
+		{"bar chart", BarChartWithOptions(map[string]int{"errors": 12, "warnings": 40}, 0, opts)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, line := range strings.Split(tt.output, "\n") {
+				if StringWidth(line) > opts.Width {
+					t.Errorf("line exceeds width %d: %q", opts.Width, line)
+				}
+			}
+		})
+	}
+}
+
+func TestBoxWrapsContent(t *testing.T) {
+	opts := colorOptions()
+	opts.Width = 20
+
+	box := BoxWithOptions("", "one two three four five six", opts)
+	assertUniformWidth(t, "Box", box)
+
+	if !contains(box, "six") {
+		t.Errorf("BoxWithOptions() dropped wrapped content:\n%s", box)
+	}
+}
+
+func TestTableWrapsShrunkColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Width = 25
+
+	table := TableWithOptions([]string{"ID", "Message"}, [][]string{
+		{"1", "connection refused by upstream host"},
+	}, opts)
+	assertUniformWidth(t, "Table", table)
+
+	if lines := strings.Split(table, "\n"); len(lines) < 4 {
+		t.Errorf("expected wrapped message cell, got:\n%s", table)
+	}
+}
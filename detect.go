@@ -0,0 +1,167 @@
+package termfmt
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// vteHyperlinkVersion is the first VTE_VERSION with OSC 8 hyperlink support
+	vteHyperlinkVersion = 5000
+)
+
+// fdWriter is implemented by writers backed by a file descriptor, such as *os.File
+type fdWriter interface {
+	Fd() uintptr
+}
+
+// Capabilities is a snapshot of what the output terminal can display. It is
+// computed once by DefaultOptions or Detect and carried in TerminalOptions,
+// so rendering never consults the process environment. Tests can construct
+// it directly to render deterministically.
+type Capabilities struct {
+	ColorLevel ColorLevel // Color level to render at, ColorLevelNone when color is disabled
+	Unicode    bool       // Box-drawing and other non-ASCII characters render correctly
+	Emoji      bool       // Emoji render as pictures
+	Hyperlinks bool       // OSC 8 hyperlinks are supported
+	TTY        bool       // Output is a terminal
+	Width      int        // Terminal width in columns, 0 when unknown
+	Height     int        // Terminal height in rows, 0 when unknown
+}
+
+// DetectCapabilities inspects w and env and returns the capabilities of the
+// output. A nil writer is assumed to be a terminal, which matches the
+// behavior of components rendered to strings.
+func DetectCapabilities(w io.Writer, env Env) Capabilities {
+	caps := Capabilities{TTY: w == nil || IsTerminal(w)}
+
+	if ResolveColor(ColorAuto, env, caps.TTY) {
+		caps.ColorLevel = max(ColorLevelFromEnv(env), ColorLevel16)
+	}
+
+	caps.Unicode = detectUnicode(env)
+	caps.Emoji = caps.Unicode && detectEmoji(env)
+	caps.Hyperlinks = caps.TTY && detectHyperlinks(env)
+
+	if w != nil {
+		caps.Width, caps.Height, _ = terminalSizeEnv(w, env)
+	}
+
+	return caps
+}
+
+// Detect inspects w and the environment and returns options suited to it.
+// Color is decided by ResolveColor, so it is disabled when w is not a
+// terminal unless forced by the environment, and Width and Height come from
+// the terminal size, the COLUMNS and LINES variables, or DefaultTerminalWidth,
+// in that order.
+func Detect(w io.Writer) *TerminalOptions {
+	return detectEnv(w, OSEnv())
+}
+
+// detectEnv implements Detect with an injectable environment
+func detectEnv(w io.Writer, env Env) *TerminalOptions {
+	caps := DetectCapabilities(w, env)
+
+	opts := defaultOptions()
+	opts.Capabilities = &caps
+	opts.Color = caps.ColorLevel != ColorLevelNone
+	opts.Emoji = caps.Emoji
+
+	if caps.Width > 0 {
+		opts.Width = caps.Width
+		opts.Height = caps.Height
+	}
+
+	return opts
+}
+
+// IsTerminal reports whether w is connected to a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(fdWriter)
+	if !ok {
+		return false
+	}
+
+	return isTerminal(f)
+}
+
+// TerminalSize returns the number of columns and rows of the terminal behind
+// w, falling back to the COLUMNS and LINES environment variables. ok is false
+// when the width cannot be determined.
+func TerminalSize(w io.Writer) (cols, rows int, ok bool) {
+	return terminalSizeEnv(w, OSEnv())
+}
+
+// terminalSizeEnv implements TerminalSize with an injectable environment
+func terminalSizeEnv(w io.Writer, env Env) (cols, rows int, ok bool) {
+	if f, isFd := w.(fdWriter); isFd {
+		if cols, rows, ok = terminalSize(f); ok {
+			return cols, rows, true
+		}
+	}
+
+	cols = envInt(env, "COLUMNS")
+	rows = envInt(env, "LINES")
+
+	return cols, rows, cols > 0
+}
+
+// envInt returns the positive integer value of an environment variable, or 0
+func envInt(env Env, key string) int {
+	n, err := strconv.Atoi(env(key))
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+// detectUnicode reports whether the locale uses UTF-8. Without any locale
+// variables Unicode is assumed unless the terminal is dumb.
+func detectUnicode(env Env) bool {
+	if env("TERM") == "dumb" {
+		return false
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(env(key)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+
+	return true
+}
+
+// detectEmoji reports whether the terminal likely renders emoji
+func detectEmoji(env Env) bool {
+	if env("TERM") == "linux" {
+		// The Linux console has no emoji glyphs
+		return false
+	}
+
+	return strings.Contains(env("TERM"), "256color") ||
+		env("TERM_PROGRAM") != "" ||
+		env("WT_SESSION") != ""
+}
+
+// detectHyperlinks reports whether the terminal is known to support OSC 8
+func detectHyperlinks(env Env) bool {
+	switch env("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+
+	if env("WT_SESSION") != "" || env("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+
+	if vte, err := strconv.Atoi(env("VTE_VERSION")); err == nil && vte >= vteHyperlinkVersion {
+		return true
+	}
+
+	term := env("TERM")
+
+	return strings.Contains(term, "kitty") || strings.HasPrefix(term, "foot") || term == "alacritty"
+}
@@ -0,0 +1,140 @@
+package termfmt
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestDetectNonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := detectEnv(&buf, MapEnv(map[string]string{"TERM": "xterm-256color"}))
+	if opts.Color {
+		t.Error("Detect() enabled color for a non-terminal writer")
+	}
+
+	if opts.Width != DefaultTerminalWidth {
+		t.Errorf("Detect() Width = %d, want %d", opts.Width, DefaultTerminalWidth)
+	}
+}
+
+func TestDetectPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer r.Close()
+	defer w.Close()
+
+	if IsTerminal(w) {
+		t.Error("IsTerminal() reported a pipe as a terminal")
+	}
+}
+
+func TestTerminalSizeEnvFallback(t *testing.T) {
+	opts := detectEnv(&bytes.Buffer{}, MapEnv(map[string]string{"COLUMNS": "132", "LINES": "43"}))
+	if opts.Width != 132 || opts.Height != 43 {
+		t.Errorf("Detect() size = %dx%d, want 132x43", opts.Width, opts.Height)
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	caps := DetectCapabilities(nil, MapEnv(map[string]string{
+		"TERM":         "xterm-256color",
+		"TERM_PROGRAM": "WezTerm",
+		"LANG":         "en_US.UTF-8",
+	}))
+
+	if caps.ColorLevel != ColorLevel256 || !caps.Unicode || !caps.Emoji || !caps.Hyperlinks {
+		t.Errorf("DetectCapabilities() = %+v, want 256 colors, unicode, emoji and hyperlinks", caps)
+	}
+
+	caps = DetectCapabilities(nil, MapEnv(map[string]string{"TERM": "xterm-256color", "LANG": "C"}))
+	if caps.Unicode || caps.Emoji {
+		t.Errorf("DetectCapabilities() with LANG=C = %+v, want no unicode or emoji", caps)
+	}
+}
+
+func TestCapabilitiesOverrideEnvironment(t *testing.T) {
+	opts := optionsWith(DetectCapabilities(nil, MapEnv(map[string]string{"NO_COLOR": "1", "TERM": "dumb"})))
+	opts.Capabilities = &Capabilities{ColorLevel: ColorLevel16, Unicode: true, Emoji: true}
+
+	if got := Colorize("ok", "green", opts); !contains(got, "\033[") {
+		t.Errorf("Colorize() = %q, want color from Capabilities", got)
+	}
+
+	if got := GetEmoji("success", opts); got != "✅" {
+		t.Errorf("GetEmoji() = %q, want emoji from Capabilities", got)
+	}
+
+	opts.Capabilities = &Capabilities{}
+	if got := Colorize("ok", "green", opts); got != "ok" {
+		t.Errorf("Colorize() = %q, want plain text without color capability", got)
+	}
+}
+
+func TestUnsetCapabilitiesUseProcessSnapshot(t *testing.T) {
+	level := processCapabilities().ColorLevel
+
+	if got := activeColorLevel(&TerminalOptions{Color: true}); got != level {
+		t.Errorf("color level without Capabilities = %s, want the process snapshot's %s", got, level)
+	}
+
+	style := NewStyle().Bold(true)
+	if got, want := style.Render("x"), style.ColorLevel(level).Render("x"); got != want {
+		t.Errorf("Style.Render() = %q, want %q rendered at the process snapshot's level", got, want)
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	opts := colorOptions()
+	opts.Capabilities = &Capabilities{Hyperlinks: true}
+
+	got := Hyperlink("docs", "https://example.com", opts)
+	if got != "\033]8;;https://example.com\033\\docs\033]8;;\033\\" {
+		t.Errorf("Hyperlink() = %q, want OSC 8 link", got)
+	}
+
+	if StringWidth(got) != 4 {
+		t.Errorf("StringWidth(Hyperlink()) = %d, want 4", StringWidth(got))
+	}
+
+	opts.Capabilities = &Capabilities{}
+	if got := Hyperlink("docs", "https://example.com", opts); got != "docs (https://example.com)" {
+		t.Errorf("Hyperlink() fallback = %q", got)
+	}
+}
+
+func TestDetectPipeColorAlways(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer r.Close()
+	defer w.Close()
+
+	opts := detectEnv(w, MapEnv(map[string]string{"TERM": "xterm"}))
+	opts.ColorMode = ColorAlways
+
+	if got := Success("ok", opts); !contains(got, "\033[") {
+		t.Errorf("Success() with ColorAlways on a pipe = %q, want color", got)
+	}
+
+	if got := Colorize("ok", "green", opts); !contains(got, "\033[") {
+		t.Errorf("Colorize() with ColorAlways on a pipe = %q, want color", got)
+	}
+
+	if got := NewStyle().Bold(true).RenderWithOptions("ok", opts); !contains(got, "\033[") {
+		t.Errorf("Style.RenderWithOptions() with ColorAlways on a pipe = %q, want color", got)
+	}
+
+	opts = detectEnv(w, MapEnv(map[string]string{"FORCE_COLOR": "1", "TERM": "xterm"}))
+	opts.ColorMode = ColorNever
+
+	if got := Success("ok", opts); contains(got, "\033[") {
+		t.Errorf("Success() with ColorNever = %q, want plain text", got)
+	}
+}
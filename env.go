@@ -0,0 +1,81 @@
+package termfmt
+
+import (
+	"os"
+	"strings"
+)
+
+// Env looks up environment variables. Passing an Env instead of reading the
+// process environment lets tests and embedders control color and capability
+// decisions without mutating os state.
+type Env func(key string) string
+
+// OSEnv returns an Env backed by the process environment
+func OSEnv() Env {
+	return os.Getenv
+}
+
+// MapEnv returns an Env backed by a map, for tests and embedders
+func MapEnv(vars map[string]string) Env {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+// ColorMode is an explicit choice about color output
+type ColorMode int
+
+const (
+	// ColorAuto decides from the environment and the output, see ResolveColor
+	ColorAuto ColorMode = iota
+	// ColorAlways emits color regardless of the environment
+	ColorAlways
+	// ColorNever never emits color
+	ColorNever
+)
+
+// ResolveColor decides whether color output should be used. The first rule
+// that applies wins:
+//
+//  1. An explicit mode: ColorAlways enables and ColorNever disables color.
+//  2. NO_COLOR set to any non-empty value disables color (https://no-color.org).
+//  3. FORCE_COLOR set to a non-empty value enables color, except for the
+//     values "0" and "false", which disable it. CLICOLOR_FORCE set to a
+//     non-empty value other than "0" enables color; "0" is ignored and the
+//     later rules decide.
+//  4. CLICOLOR=0 disables color.
+//  5. Output that is not a terminal disables color.
+//  6. TERM decides: unset or "dumb" disables color, anything else enables it.
+func ResolveColor(mode ColorMode, env Env, isTTY bool) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	case ColorAuto:
+	}
+
+	if env("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := strings.ToLower(env("FORCE_COLOR")); force != "" {
+		return force != "0" && force != "false"
+	}
+
+	if env("CLICOLOR_FORCE") != "" && env("CLICOLOR_FORCE") != "0" {
+		return true
+	}
+
+	if env("CLICOLOR") == "0" {
+		return false
+	}
+
+	if !isTTY {
+		return false
+	}
+
+	term := env("TERM")
+
+	return term != "" && term != "dumb"
+}
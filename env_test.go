@@ -0,0 +1,60 @@
+package termfmt
+
+import (
+	"testing"
+)
+
+func TestResolveColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  ColorMode
+		env   map[string]string
+		isTTY bool
+		want  bool
+	}{
+		{"always overrides NO_COLOR", ColorAlways, map[string]string{"NO_COLOR": "1"}, false, true},
+		{"never overrides FORCE_COLOR", ColorNever, map[string]string{"FORCE_COLOR": "1", "TERM": "xterm"}, true, false},
+		{"NO_COLOR beats color terminal", ColorAuto, map[string]string{"NO_COLOR": "1", "TERM": "xterm-256color"}, true, false},
+		{"NO_COLOR beats FORCE_COLOR", ColorAuto, map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, true, false},
+		{"empty NO_COLOR is ignored", ColorAuto, map[string]string{"NO_COLOR": "", "TERM": "xterm"}, true, true},
+		{"FORCE_COLOR without tty", ColorAuto, map[string]string{"FORCE_COLOR": "1"}, false, true},
+		{"FORCE_COLOR=0 disables", ColorAuto, map[string]string{"FORCE_COLOR": "0", "TERM": "xterm"}, true, false},
+		{"CLICOLOR_FORCE without tty", ColorAuto, map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"}, false, true},
+		{"CLICOLOR_FORCE=0 is ignored", ColorAuto, map[string]string{"CLICOLOR_FORCE": "0", "TERM": "xterm"}, false, false},
+		{"CLICOLOR_FORCE=0 on a tty", ColorAuto, map[string]string{"CLICOLOR_FORCE": "0", "TERM": "xterm"}, true, true},
+		{"CLICOLOR=0 disables", ColorAuto, map[string]string{"CLICOLOR": "0", "TERM": "xterm"}, true, false},
+		{"not a tty", ColorAuto, map[string]string{"TERM": "xterm-256color"}, false, false},
+		{"dumb terminal", ColorAuto, map[string]string{"TERM": "dumb"}, true, false},
+		{"no TERM", ColorAuto, map[string]string{}, true, false},
+		{"color terminal", ColorAuto, map[string]string{"TERM": "xterm-kitty"}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveColor(tt.mode, MapEnv(tt.env), tt.isTTY); got != tt.want {
+				t.Errorf("ResolveColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorLevelFromEnv(t *testing.T) {
+	env := MapEnv(map[string]string{"FORCE_COLOR": "3", "TERM": "dumb"})
+	if got := ColorLevelFromEnv(env); got != ColorLevelTrueColor {
+		t.Errorf("ColorLevelFromEnv() = %s, want truecolor", got)
+	}
+}
+
+func TestNoColorDisablesHelpers(t *testing.T) {
+	opts := optionsWith(DetectCapabilities(nil, MapEnv(map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"})))
+
+	if got := Error("failed", opts); got != "failed" {
+		t.Errorf("Error() with NO_COLOR = %q, want plain text", got)
+	}
+}
+
+// colorOptions returns the default options for a 16-color terminal with no
+// color overrides, without consulting the process environment
+func colorOptions() *TerminalOptions {
+	return optionsWith(DetectCapabilities(nil, MapEnv(map[string]string{"TERM": "xterm"})))
+}
@@ -1,5 +1,7 @@
 package termfmt
 
+import "sync"
+
 // Formatter defines the interface for terminal output formatting
 type Formatter interface {
 	Format(data interface{}) ([]byte, error)
@@ -7,11 +9,34 @@ type Formatter interface {
 
 // TerminalOptions configures terminal formatting behavior
 type TerminalOptions struct {
-	Color     bool // Enable colored output
-	Emoji     bool // Enable emoji output
-	Width     int  // Terminal width for formatting
-	Compact   bool // Use compact formatting
-	ShowIcons bool // Show icons/symbols
+	Color     bool      // Enable colored output
+	ColorMode ColorMode // Explicit color choice that overrides Color, see ResolveColor
+	Emoji     bool      // Enable emoji output
+	Width     int       // Terminal width for formatting
+	Height    int       // Terminal height in rows, 0 when unknown
+	Compact   bool      // Use compact formatting
+	ShowIcons bool      // Show icons/symbols
+
+	// Accessible pairs every semantic color with a text label such as [ERR]
+	// so that meaning never depends on color alone
+	Accessible bool
+
+	Theme  *Theme       // Colors and styles for components, nil for DefaultTheme
+	Border *BorderStyle // Border for boxes and tables, nil for each component's default
+
+	// Ordering orders the entries of BarChart, Summary and map output. The
+	// zero value sorts maps by key and keeps the order of pair slices.
+	Ordering Ordering
+
+	// TableRecords draws tables that do not fit Width without wrapping their
+	// headers, even with optional columns hidden, as one block of header and
+	// value lines per row
+	TableRecords bool
+
+	// Capabilities is the terminal capability snapshot consulted by every
+	// component and helper. DefaultOptions and Detect fill it in; when nil the
+	// snapshot of the process environment taken on first use is consulted.
+	Capabilities *Capabilities
 }
 
 const (
@@ -19,13 +44,43 @@ const (
 	DefaultTerminalWidth = 80
 )
 
-// DefaultOptions returns sensible default options
+// DefaultOptions returns sensible default options, with capabilities
+// detected once from the process environment
 func DefaultOptions() *TerminalOptions {
+	return optionsWith(DetectCapabilities(nil, OSEnv()))
+}
+
+// processCapabilities is the capability snapshot of the process environment,
+// detected on first use for options built without one and for functions
+// called without options
+var processCapabilities = sync.OnceValue(func() Capabilities { //nolint:gochecknoglobals // detected once per process
+	return DetectCapabilities(nil, OSEnv())
+})
+
+// defaultOptions returns DefaultOptions without detecting again, for
+// functions called without options
+func defaultOptions() *TerminalOptions {
+	return optionsWith(processCapabilities())
+}
+
+// optionsWith returns the default options with the given capabilities
+func optionsWith(caps Capabilities) *TerminalOptions {
 	return &TerminalOptions{
-		Color:     true,
-		Emoji:     true,
-		Width:     DefaultTerminalWidth,
-		Compact:   false,
-		ShowIcons: true,
+		Color:        true,
+		Emoji:        true,
+		Width:        DefaultTerminalWidth,
+		Compact:      false,
+		ShowIcons:    true,
+		Capabilities: &caps,
 	}
 }
+
+// capabilities returns the capability snapshot in opts, or that of the
+// process environment when the options were built without one
+func (opts *TerminalOptions) capabilities() Capabilities {
+	if opts.Capabilities != nil {
+		return *opts.Capabilities
+	}
+
+	return processCapabilities()
+}
@@ -33,6 +33,18 @@ func TestNewTerminal(t *testing.T) {
 	_ = formatter
 }
 
+func TestNewTerminalDetectsStdout(t *testing.T) {
+	env := MapEnv(map[string]string{"TERM": "xterm", "FORCE_COLOR": "1"})
+
+	if opts := newTerminalEnv(true, env).(*terminalFormatter).options; !opts.Color || opts.Capabilities == nil {
+		t.Errorf("NewTerminal(true) with FORCE_COLOR: Color = %v, Capabilities = %v", opts.Color, opts.Capabilities)
+	}
+
+	if opts := newTerminalEnv(false, env).(*terminalFormatter).options; opts.Color {
+		t.Error("NewTerminal(false) enabled color")
+	}
+}
+
 func TestBox(t *testing.T) {
 	result := Box("Test", "Content")
 	if result == "" {
@@ -0,0 +1,149 @@
+package termfmt
+
+import "strings"
+
+// GridColumn sizes one column of a Grid. Width takes precedence over Percent,
+// and columns with neither share the remaining width by Flex weight.
+type GridColumn struct {
+	Width    int // Fixed width in cells
+	Percent  int // Share of the grid width, from 1 to 100
+	Flex     int // Weight for the remaining width, 0 counts as 1
+	MinWidth int // Narrowest usable width, cells wrap onto new rows below it
+}
+
+// GridCell renders the content of a grid cell. It receives a copy of the
+// options with Width set to the width of its column, so components such as
+// BoxWithOptions and TableWithOptions fit the cell.
+type GridCell func(opts *TerminalOptions) string
+
+// TextCell returns a cell that wraps text to the column width
+func TextCell(text string) GridCell {
+	return func(opts *TerminalOptions) string {
+		return strings.Join(wrapLines(text, opts.Width), "\n")
+	}
+}
+
+// Grid lays out cells in columns across opts.Width. Cells fill the columns
+// from left to right and continue on the next row. When the columns do not
+// fit at their minimum widths, cells flow into as many equal-width columns as
+// fit, down to a single column, so the same grid adapts to narrow terminals.
+//
+//	grid := termfmt.Grid{
+//		Columns: []termfmt.GridColumn{{Percent: 30, MinWidth: 24}, {Flex: 1, MinWidth: 30}},
+//		Gap:     2,
+//	}
+//	fmt.Println(grid.Render([]termfmt.GridCell{statusCell, tableCell}, opts))
+type Grid struct {
+	Columns []GridColumn
+	Gap     int               // Columns between cells
+	RowGap  int               // Blank lines between rows
+	Align   VerticalAlignment // Vertical alignment of cells shorter than their row
+}
+
+// Render lays out cells and returns the grid as a multi-line string
+func (g Grid) Render(cells []GridCell, opts *TerminalOptions) string {
+	if len(cells) == 0 || len(g.Columns) == 0 {
+		return ""
+	}
+
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	total := opts.Width
+	if total <= 0 {
+		total = DefaultTerminalWidth
+	}
+
+	widths := g.columnWidths(total)
+
+	rows := make([]string, 0, len(cells)/len(widths)+1)
+
+	for start := 0; start < len(cells); start += len(widths) {
+		end := min(start+len(widths), len(cells))
+
+		blocks := make([]string, 0, end-start)
+		for i, cell := range cells[start:end] {
+			blocks = append(blocks, renderGridCell(cell, widths[i], opts))
+		}
+
+		rows = append(rows, JoinHorizontal(g.Align, g.Gap, blocks...))
+	}
+
+	return JoinVertical(AlignLeft, g.RowGap, rows...)
+}
+
+// columnWidths distributes total cells among the columns, falling back to
+// fewer equal columns when they do not fit
+func (g Grid) columnWidths(total int) []int {
+	gap := max(g.Gap, 0)
+	available := total - gap*(len(g.Columns)-1)
+
+	widths := make([]int, len(g.Columns))
+	remaining := available
+	flexTotal := 0
+
+	for i, col := range g.Columns {
+		switch {
+		case col.Width > 0:
+			widths[i] = col.Width
+		case col.Percent > 0:
+			widths[i] = available * col.Percent / percentMultiplier
+		default:
+			flexTotal += max(col.Flex, 1)
+			continue
+		}
+
+		remaining -= widths[i]
+	}
+
+	// Each flexible column takes its share of what is left, so the rounding
+	// remainder ends up in the last one
+	for i, col := range g.Columns {
+		if col.Width > 0 || col.Percent > 0 {
+			continue
+		}
+
+		weight := max(col.Flex, 1)
+		widths[i] = max(remaining, 0) * weight / flexTotal
+		flexTotal -= weight
+		remaining -= widths[i]
+	}
+
+	used := 0
+	minWidest := 1
+
+	for i, col := range g.Columns {
+		widths[i] = max(widths[i], col.MinWidth, 1)
+		used += widths[i]
+		minWidest = max(minWidest, col.MinWidth, col.Width)
+	}
+
+	if used <= available {
+		return widths
+	}
+
+	// Flow into as many equal columns as fit at the widest minimum
+	count := max(min((total+gap)/(minWidest+gap), len(g.Columns)-1), 1)
+	width := max((total-gap*(count-1))/count, 1)
+
+	fallback := make([]int, count)
+	for i := range fallback {
+		fallback[i] = width
+	}
+
+	return fallback
+}
+
+// renderGridCell renders cell at width and pads every line to exactly width cells
+func renderGridCell(cell GridCell, width int, opts *TerminalOptions) string {
+	cellOpts := *opts
+	cellOpts.Width = width
+
+	lines := strings.Split(cell(&cellOpts), "\n")
+	for i, line := range lines {
+		lines[i] = padRight(Truncate(line, width), width)
+	}
+
+	return strings.Join(lines, "\n")
+}
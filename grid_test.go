@@ -0,0 +1,95 @@
+package termfmt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGridColumnWidths(t *testing.T) {
+	grid := Grid{
+		Columns: []GridColumn{{Width: 10}, {Percent: 25}, {Flex: 1}, {Flex: 2}},
+		Gap:     2,
+	}
+
+	// 86 cells minus three gaps leaves 80: 10 fixed, 20 percent, 50 flex split 1:2
+	if got, want := grid.columnWidths(86), []int{10, 20, 16, 34}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columnWidths() = %v, want %v", got, want)
+	}
+}
+
+func TestGridWrapsWhenNarrow(t *testing.T) {
+	grid := Grid{
+		Columns: []GridColumn{{Flex: 1, MinWidth: 30}, {Flex: 1, MinWidth: 30}, {Flex: 1, MinWidth: 30}},
+		Gap:     1,
+	}
+
+	if got := grid.columnWidths(200); len(got) != 3 {
+		t.Errorf("columnWidths(200) = %v, want 3 columns", got)
+	}
+
+	if got := grid.columnWidths(80); !reflect.DeepEqual(got, []int{39, 39}) {
+		t.Errorf("columnWidths(80) = %v, want two equal columns", got)
+	}
+
+	if got := grid.columnWidths(40); !reflect.DeepEqual(got, []int{40}) {
+		t.Errorf("columnWidths(40) = %v, want a single column", got)
+	}
+}
+
+func TestGridRendersComponents(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	grid := Grid{Columns: []GridColumn{{Percent: 40}, {Flex: 1}}, Gap: 2}
+	cells := []GridCell{
+		func(o *TerminalOptions) string {
+			return BoxWithOptions("Status", "all services are running normally", o)
+		},
+		func(o *TerminalOptions) string {
+			return TableWithOptions([]string{"Service", "State"}, [][]string{{"api", "running"}}, o)
+		},
+		TextCell("a footer line that wraps inside its cell"),
+	}
+
+	for _, width := range []int{80, 200} {
+		opts.Width = width
+
+		out := grid.Render(cells, opts)
+		assertUniformWidth(t, "Grid", out)
+
+		if w := StringWidth(strings.Split(out, "\n")[0]); w != width {
+			t.Errorf("grid width = %d, want %d", w, width)
+		}
+
+		if !contains(out, "Status") || !contains(out, "running") || !contains(out, "footer") {
+			t.Errorf("grid is missing cell content:\n%s", out)
+		}
+	}
+}
+
+func TestGridRendersProgressBars(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	grid := Grid{Columns: []GridColumn{{Flex: 1}, {Flex: 1}, {Flex: 1}, {Flex: 1}}, Gap: 2}
+	progress := func(current int) GridCell {
+		return func(o *TerminalOptions) string {
+			return ProgressBarWithOptions(current, 4, o.Width, o)
+		}
+	}
+	cells := []GridCell{progress(1), progress(2), progress(3), progress(4)}
+
+	for _, width := range []int{80, 200} {
+		opts.Width = width
+
+		out := grid.Render(cells, opts)
+		assertUniformWidth(t, "Grid", out)
+
+		for _, percent := range []string{"25.0%", "50.0%", "75.0%", "100.0%"} {
+			if !contains(out, percent) {
+				t.Errorf("grid at width %d is missing %s:\n%s", width, percent, out)
+			}
+		}
+	}
+}
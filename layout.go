@@ -0,0 +1,76 @@
+package termfmt
+
+import "strings"
+
+// JoinHorizontal places multi-line blocks side by side, separated by gap
+// columns. Every block is padded to its widest line and shorter blocks are
+// padded with blank lines according to align, so the result is rectangular.
+//
+//	dashboard := termfmt.JoinHorizontal(termfmt.AlignTop, 2, box, chart)
+func JoinHorizontal(align VerticalAlignment, gap int, blocks ...string) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	columns := make([][]string, len(blocks))
+	widths := make([]int, len(blocks))
+	height := 0
+
+	for i, block := range blocks {
+		columns[i] = strings.Split(block, "\n")
+		widths[i] = blockWidth(columns[i])
+		height = max(height, len(columns[i]))
+	}
+
+	separator := strings.Repeat(" ", max(gap, 0))
+	rows := make([]string, height)
+
+	for i, lines := range columns {
+		lines = alignLines(lines, height, align)
+
+		for row, line := range lines {
+			if i > 0 {
+				rows[row] += separator
+			}
+
+			rows[row] += padRight(line, widths[i])
+		}
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// JoinVertical stacks multi-line blocks, separated by gap blank lines. Lines
+// are padded to the width of the widest block according to align.
+func JoinVertical(align Alignment, gap int, blocks ...string) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	var lines []string
+
+	for i, block := range blocks {
+		if i > 0 {
+			lines = append(lines, make([]string, max(gap, 0))...)
+		}
+
+		lines = append(lines, strings.Split(block, "\n")...)
+	}
+
+	width := blockWidth(lines)
+	for i, line := range lines {
+		lines[i] = alignText(line, width, align)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// blockWidth returns the display width of the widest line
+func blockWidth(lines []string) int {
+	width := 0
+	for _, line := range lines {
+		width = max(width, StringWidth(line))
+	}
+
+	return width
+}
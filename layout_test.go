@@ -0,0 +1,51 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinHorizontal(t *testing.T) {
+	got := JoinHorizontal(AlignTop, 1, "a\nbb\nc", "日本", Red+"x"+Reset)
+	want := "a  日本 x\nbb       \nc        "
+
+	if StripANSI(got) != want {
+		t.Errorf("JoinHorizontal() = %q, want %q", StripANSI(got), want)
+	}
+
+	assertUniformWidth(t, "JoinHorizontal", got)
+}
+
+func TestJoinHorizontalAlignment(t *testing.T) {
+	got := JoinHorizontal(AlignBottom, 0, "1\n2\n3", "x")
+	if want := "1 \n2 \n3x"; got != want {
+		t.Errorf("JoinHorizontal(AlignBottom) = %q, want %q", got, want)
+	}
+
+	got = JoinHorizontal(AlignMiddle, 0, "1\n2\n3", "x")
+	if want := "1 \n2x\n3 "; got != want {
+		t.Errorf("JoinHorizontal(AlignMiddle) = %q, want %q", got, want)
+	}
+}
+
+func TestJoinVertical(t *testing.T) {
+	got := JoinVertical(AlignCenter, 1, "abcde", "x")
+	if want := "abcde\n     \n  x  "; got != want {
+		t.Errorf("JoinVertical() = %q, want %q", got, want)
+	}
+}
+
+func TestJoinComponents(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	box := BoxWithOptions("Status", "ok", opts)
+	table := TableWithOptions([]string{"Name", "CPU"}, [][]string{{"api", "45%"}}, opts)
+
+	screen := JoinHorizontal(AlignTop, 2, box, table)
+	assertUniformWidth(t, "dashboard", screen)
+
+	if lines := strings.Split(screen, "\n"); len(lines) != strings.Count(box, "\n")+1 {
+		t.Errorf("JoinHorizontal() has %d lines, want the height of the box", len(lines))
+	}
+}
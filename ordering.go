@@ -0,0 +1,207 @@
+package termfmt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// OrderBy selects how the entries of BarChart, Summary and map output are ordered
+type OrderBy int
+
+const (
+	// OrderInsertion keeps the order of pair slices such as those passed to
+	// BarChartPairs and SummaryPairs. Maps have no order and are sorted by key.
+	OrderInsertion OrderBy = iota
+	// OrderByKey sorts entries by key, comparing digit runs as numbers
+	OrderByKey
+	// OrderByValue sorts entries by ascending value, then by key
+	OrderByValue
+	// OrderByValueDesc sorts entries by descending value, then by key
+	OrderByValueDesc
+)
+
+// Ordering controls the order of map-driven output. The zero value keeps the
+// order of pair slices, sorts maps by key and shows every entry, so output is
+// the same on every run.
+type Ordering struct {
+	By OrderBy
+
+	// Limit shows only the first Limit entries and merges the others into one
+	// entry labelled Other, 0 shows every entry. Numeric values are summed,
+	// other values are replaced by a count such as "12 more".
+	Limit int
+	Other string // Label of the merged entry, "other" when empty
+}
+
+// KeyValue is one entry of a Summary or of Formatter output given as a pair
+// slice instead of a map, to keep its order
+type KeyValue struct {
+	Key   string
+	Value interface{}
+}
+
+// Bar is one bar of a chart drawn by BarChartPairs
+type Bar struct {
+	Label string
+	Value int
+}
+
+// defaultOtherLabel labels the entry that merges those beyond Ordering.Limit
+const defaultOtherLabel = "other"
+
+// mapEntries returns the entries of a map in key order
+func mapEntries(m map[string]interface{}) []KeyValue {
+	entries := make([]KeyValue, 0, len(m))
+	for key, value := range m {
+		entries = append(entries, KeyValue{Key: key, Value: value})
+	}
+
+	sortEntriesByKey(entries)
+
+	return entries
+}
+
+// barEntries returns the bars of a map in key order
+func barEntries(m map[string]int) []Bar {
+	bars := make([]Bar, 0, len(m))
+	for label, value := range m {
+		bars = append(bars, Bar{Label: label, Value: value})
+	}
+
+	sort.Slice(bars, func(i, j int) bool {
+		return compareNatural(bars[i].Label, bars[j].Label) < 0
+	})
+
+	return bars
+}
+
+// sortEntriesByKey sorts entries by key, comparing digit runs as numbers
+func sortEntriesByKey(entries []KeyValue) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return compareNatural(entries[i].Key, entries[j].Key) < 0
+	})
+}
+
+// orderEntries returns entries in the configured order, merging those beyond
+// the limit. Entries from a map arrive sorted by key, so OrderInsertion and
+// OrderByKey keep them as they are.
+func orderEntries(entries []KeyValue, ordering Ordering) []KeyValue {
+	ordered := make([]KeyValue, len(entries))
+	copy(ordered, entries)
+
+	switch ordering.By {
+	case OrderInsertion:
+	case OrderByKey:
+		sortEntriesByKey(ordered)
+	case OrderByValue, OrderByValueDesc:
+		sortEntriesByKey(ordered)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			c := compareValues(ordered[i].Value, ordered[j].Value)
+			if ordering.By == OrderByValueDesc {
+				return c > 0
+			}
+
+			return c < 0
+		})
+	}
+
+	if ordering.Limit <= 0 || len(ordered) <= ordering.Limit {
+		return ordered
+	}
+
+	other := ordering.Other
+	if other == "" {
+		other = defaultOtherLabel
+	}
+
+	rest := ordered[ordering.Limit:]
+
+	return append(ordered[:ordering.Limit:ordering.Limit], KeyValue{Key: other, Value: mergeValues(rest)})
+}
+
+// orderBars returns bars in the configured order, merging those beyond the limit
+func orderBars(bars []Bar, ordering Ordering) []Bar {
+	entries := make([]KeyValue, len(bars))
+	for i, bar := range bars {
+		entries[i] = KeyValue{Key: bar.Label, Value: bar.Value}
+	}
+
+	entries = orderEntries(entries, ordering)
+
+	ordered := make([]Bar, len(entries))
+	for i, entry := range entries {
+		value, _ := entry.Value.(int) //nolint:errcheck // bar values and their sums are ints
+		ordered[i] = Bar{Label: entry.Key, Value: value}
+	}
+
+	return ordered
+}
+
+// mergeValues combines the values of entries beyond the limit: integers and
+// floats are summed and anything else is counted
+func mergeValues(entries []KeyValue) interface{} {
+	var (
+		intSum   int64
+		floatSum float64
+		isFloat  bool
+	)
+
+	for _, entry := range entries {
+		v := reflect.ValueOf(entry.Value)
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			intSum += v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			intSum += int64(v.Uint()) //nolint:gosec // counts shown in output
+		case reflect.Float32, reflect.Float64:
+			floatSum += v.Float()
+			isFloat = true
+		default:
+			return fmt.Sprintf("%d more", len(entries))
+		}
+	}
+
+	if isFloat {
+		return floatSum + float64(intSum)
+	}
+
+	return int(intSum)
+}
+
+// compareValues compares two values, numerically when both are numbers and
+// by their text otherwise, returning -1, 0 or 1
+func compareValues(a, b interface{}) int {
+	x, okA := numericValue(a)
+	y, okB := numericValue(b)
+
+	if okA && okB {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return compareNatural(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// numericValue returns v as a float64 when it is a number
+func numericValue(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
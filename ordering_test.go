@@ -0,0 +1,148 @@
+package termfmt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func entryKeys(entries []KeyValue) []string {
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+
+	return keys
+}
+
+func TestOrderEntries(t *testing.T) {
+	entries := []KeyValue{{"node10", 3}, {"node2", 9}, {"api", 1}, {"db", 9}}
+
+	tests := []struct {
+		name     string
+		ordering Ordering
+		want     []string
+	}{
+		{"default", Ordering{}, []string{"node10", "node2", "api", "db"}},
+		{"by key", Ordering{By: OrderByKey}, []string{"api", "db", "node2", "node10"}},
+		{"by value", Ordering{By: OrderByValue}, []string{"api", "node10", "db", "node2"}},
+		{"by value desc", Ordering{By: OrderByValueDesc}, []string{"db", "node2", "node10", "api"}},
+		{"top 2", Ordering{By: OrderByValueDesc, Limit: 2}, []string{"db", "node2", "other"}},
+	}
+
+	for _, tt := range tests {
+		if got := entryKeys(orderEntries(entries, tt.ordering)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: keys = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if entries[0].Key != "node10" {
+		t.Error("orderEntries modified its input")
+	}
+}
+
+func TestOrderEntriesOtherBucket(t *testing.T) {
+	numbers := orderEntries([]KeyValue{{"a", 5}, {"b", 2}, {"c", 1.5}}, Ordering{Limit: 1, Other: "rest"})
+	if last := numbers[len(numbers)-1]; last.Key != "rest" || last.Value != 3.5 {
+		t.Errorf("merged entry = %v, want rest: 3.5", last)
+	}
+
+	text := orderEntries([]KeyValue{{"a", "x"}, {"b", "y"}, {"c", "z"}}, Ordering{Limit: 1})
+	if last := text[len(text)-1]; last.Key != "other" || last.Value != "2 more" {
+		t.Errorf("merged entry = %v, want other: 2 more", last)
+	}
+}
+
+func TestBarChartDeterministic(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	data := map[string]int{"warnings": 40, "errors": 12, "info": 90, "debug": 5}
+
+	first := BarChartWithOptions(data, 40, opts)
+	for range 20 {
+		if got := BarChartWithOptions(data, 40, opts); got != first {
+			t.Fatalf("bar chart output changed between runs:\n%s\n%s", first, got)
+		}
+	}
+
+	lines := strings.Split(first, "\n")
+	if !strings.HasPrefix(lines[0], "debug") || !strings.HasPrefix(lines[3], "warnings") {
+		t.Errorf("bars not sorted by label:\n%s", first)
+	}
+
+	opts.Ordering = Ordering{By: OrderByValueDesc, Limit: 2}
+	lines = strings.Split(BarChartWithOptions(data, 40, opts), "\n")
+
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "info") || !strings.HasPrefix(lines[2], "other") ||
+		!strings.HasSuffix(lines[2], "│ 17") {
+		t.Errorf("top 2 with other bucket not applied: %q", lines)
+	}
+}
+
+func TestPairAPIsKeepInsertionOrder(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	bars := []Bar{{"zeta", 1}, {"alpha", 2}}
+	if chart := BarChartPairs(bars, 30, opts); !strings.HasPrefix(chart, "zeta") {
+		t.Errorf("BarChartPairs reordered bars by default:\n%s", chart)
+	}
+
+	summary := SummaryPairs("Run", []KeyValue{{"Started", "09:00"}, {"Duration", "5m"}}, opts)
+	if strings.Index(summary, "Started") > strings.Index(summary, "Duration") {
+		t.Errorf("SummaryPairs reordered items:\n%s", summary)
+	}
+
+	out, err := NewTerminalWithOptions(opts).Format([]KeyValue{{"b", 1}, {"a", 2}})
+	if err != nil || !strings.HasPrefix(string(out), "b: 1\na: 2") {
+		t.Errorf("Format([]KeyValue) = %q, %v", out, err)
+	}
+
+	opts.Ordering = Ordering{By: OrderByKey}
+	if chart := BarChartPairs(bars, 30, opts); !strings.HasPrefix(chart, "alpha") {
+		t.Errorf("BarChartPairs with OrderByKey kept the given order:\n%s", chart)
+	}
+}
+
+func TestSummaryAndFormatMapSortedByKey(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	summary := Summary("Stats", map[string]interface{}{"b": 1, "c": 2, "a": 3}, opts)
+	if !(strings.Index(summary, "a: 3") < strings.Index(summary, "b: 1") && strings.Index(summary, "b: 1") < strings.Index(summary, "c: 2")) {
+		t.Errorf("summary not sorted by key:\n%s", summary)
+	}
+
+	out, err := NewTerminalWithOptions(opts).Format(map[string]interface{}{"z": 1, "m": map[string]interface{}{"y": 1, "x": 2}})
+	if err != nil || string(out) != "m: \n  x: 2\n  y: 1\nz: 1\n" {
+		t.Errorf("Format(map) = %q, %v", out, err)
+	}
+}
+
+func TestBarChartPairsNegativeValues(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Emoji = false
+
+	chart := BarChartPairs([]Bar{{"gain", 10}, {"loss", -5}}, 30, opts)
+	lines := strings.Split(chart, "\n")
+
+	if len(lines) != 2 || contains(lines[1], "#") || !strings.HasSuffix(lines[1], "│ -5") {
+		t.Errorf("negative bar not drawn empty:\n%s", chart)
+	}
+
+	if strings.LastIndex(lines[0], "│") != strings.LastIndex(lines[1], "│") {
+		t.Errorf("negative bar has a different width:\n%s", chart)
+	}
+
+	for _, bars := range [][]Bar{{{"loss", -5}, {"debt", -2}}, {{"none", 0}}} {
+		chart = BarChartPairs(bars, 30, opts)
+		lines = strings.Split(chart, "\n")
+
+		if len(lines) != len(bars) || contains(chart, "#") || !strings.HasSuffix(lines[0], fmt.Sprintf("│ %d", bars[0].Value)) {
+			t.Errorf("bars without a positive value not drawn empty:\n%s", chart)
+		}
+	}
+}
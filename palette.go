@@ -0,0 +1,358 @@
+package termfmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	basicColors    = 16  // Number of colors in the ANSI palette
+	cubeOffset     = 16  // First xterm-256 index of the 6x6x6 color cube
+	grayOffset     = 232 // First xterm-256 index of the grayscale ramp
+	cubeSize       = 6   // Levels per channel in the color cube
+	grayStep       = 10  // Distance between grayscale ramp entries
+	grayBase       = 8   // Value of the first grayscale ramp entry
+	grayLevels     = 24  // Number of entries in the grayscale ramp
+	fgBase         = 30  // SGR code of the first normal foreground color
+	fgBrightBase   = 90  // SGR code of the first bright foreground color
+	bgOffset       = 10  // Distance between foreground and background SGR codes
+	brightDistance = 8   // Distance between a normal color and its bright variant
+	hexShortLen    = 3   // Length of a #rgb hex color
+	hexLongLen     = 6   // Length of a #rrggbb hex color
+	hexNibble      = 17  // Multiplier expanding a #rgb digit to a full channel
+	maxPaletteSize = 255 // Largest xterm-256 palette index
+)
+
+// Color is a terminal color. It holds one of the SGR constants such as Red, a
+// color name such as "red" or "bright-blue", an xterm-256 palette index such
+// as "208", or a 24-bit hex value such as "#ff8800". Colors are converted to
+// the terminal's ColorLevel when rendered, so brand colors degrade to the
+// nearest available palette entry.
+type Color string
+
+// NoColor leaves the terminal's current color unchanged
+const NoColor Color = ""
+
+// ColorLevel describes how many colors a terminal can display
+type ColorLevel int
+
+const (
+	// ColorLevelNone disables color output
+	ColorLevelNone ColorLevel = iota
+	// ColorLevel16 supports the 16 basic ANSI colors
+	ColorLevel16
+	// ColorLevel256 supports the xterm-256 palette
+	ColorLevel256
+	// ColorLevelTrueColor supports 24-bit RGB colors
+	ColorLevelTrueColor
+)
+
+// String returns a human-readable name for the level
+func (l ColorLevel) String() string {
+	switch l {
+	case ColorLevelNone:
+		return "none"
+	case ColorLevel16:
+		return "16"
+	case ColorLevel256:
+		return "256"
+	case ColorLevelTrueColor:
+		return "truecolor"
+	default:
+		return "unknown"
+	}
+}
+
+// ANSI256 returns the xterm-256 palette color with the given index
+func ANSI256(index uint8) Color {
+	return Color(strconv.Itoa(int(index)))
+}
+
+// RGB returns a 24-bit color
+func RGB(r, g, b uint8) Color {
+	return Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+}
+
+// Hex parses a "#rgb" or "#rrggbb" color, with or without the leading '#'
+func Hex(hex string) (Color, error) {
+	r, g, b, ok := parseHex(strings.TrimPrefix(hex, "#"))
+	if !ok {
+		return NoColor, fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	return RGB(r, g, b), nil
+}
+
+// DetectColorLevel determines the color level of the terminal from the
+// FORCE_COLOR, COLORTERM and TERM environment variables
+func DetectColorLevel() ColorLevel {
+	return ColorLevelFromEnv(OSEnv())
+}
+
+// ColorLevelFromEnv determines the color level from env. FORCE_COLOR values
+// "1", "2" and "3" select 16 colors, 256 colors and truecolor respectively.
+func ColorLevelFromEnv(env Env) ColorLevel {
+	switch env("FORCE_COLOR") {
+	case "1":
+		return ColorLevel16
+	case "2":
+		return ColorLevel256
+	case "3":
+		return ColorLevelTrueColor
+	}
+
+	colorTerm := strings.ToLower(env("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return ColorLevelTrueColor
+	}
+
+	term := env("TERM")
+
+	switch {
+	case term == "" || term == "dumb":
+		return ColorLevelNone
+	case strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor"):
+		return ColorLevelTrueColor
+	case strings.Contains(term, "256color"):
+		return ColorLevel256
+	default:
+		return ColorLevel16
+	}
+}
+
+// Sequence returns the escape sequence that sets c as the foreground color,
+// downsampled to level. It returns an empty string for ColorLevelNone.
+func (c Color) Sequence(level ColorLevel) string {
+	return c.sequence(level, false)
+}
+
+// BackgroundSequence returns the escape sequence that sets c as the background
+// color, downsampled to level. It returns an empty string for ColorLevelNone.
+func (c Color) BackgroundSequence(level ColorLevel) string {
+	return c.sequence(level, true)
+}
+
+// sequence renders c as a foreground or background SGR sequence
+func (c Color) sequence(level ColorLevel, background bool) string {
+	params := c.params(level, background)
+	if params == "" {
+		return ""
+	}
+
+	return "\033[" + params + "m"
+}
+
+// params returns the SGR parameters that select c at the given level
+func (c Color) params(level ColorLevel, background bool) string {
+	if level == ColorLevelNone || c == NoColor {
+		return ""
+	}
+
+	if strings.HasPrefix(string(c), "\033[") {
+		return rawColorParams(string(c), background)
+	}
+
+	if index, ok := c.paletteIndex(); ok {
+		return paletteParams(index, level, background)
+	}
+
+	hex, isHex := strings.CutPrefix(string(c), "#")
+
+	r, g, b, ok := parseHex(hex)
+	if !isHex || !ok {
+		return ""
+	}
+
+	selector := "38"
+	if background {
+		selector = "48"
+	}
+
+	switch level {
+	case ColorLevelTrueColor:
+		return fmt.Sprintf("%s;2;%d;%d;%d", selector, r, g, b)
+	case ColorLevel256:
+		return paletteParams(rgbTo256(r, g, b), level, background)
+	case ColorLevel16, ColorLevelNone:
+		return paletteParams(rgbTo16(r, g, b), level, background)
+	default:
+		return paletteParams(rgbTo16(r, g, b), level, background)
+	}
+}
+
+// paletteIndex resolves color names and numeric palette indexes
+func (c Color) paletteIndex() (int, bool) {
+	if n, err := strconv.Atoi(string(c)); err == nil {
+		return n, n >= 0 && n <= maxPaletteSize
+	}
+
+	name := strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(string(c)))
+	bright := strings.HasPrefix(name, "bright")
+	name = strings.TrimPrefix(name, "bright")
+
+	names := []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+	for i, known := range names {
+		if name == known {
+			if bright {
+				return i + brightDistance, true
+			}
+
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// paletteParams returns the SGR parameters for an xterm-256 palette index
+func paletteParams(index int, level ColorLevel, background bool) string {
+	if index >= basicColors && level < ColorLevel256 {
+		r, g, b := paletteRGB(index)
+		index = rgbTo16(r, g, b)
+	}
+
+	if index < basicColors {
+		code := fgBase + index
+		if index >= brightDistance {
+			code = fgBrightBase + index - brightDistance
+		}
+
+		if background {
+			code += bgOffset
+		}
+
+		return strconv.Itoa(code)
+	}
+
+	if background {
+		return "48;5;" + strconv.Itoa(index)
+	}
+
+	return "38;5;" + strconv.Itoa(index)
+}
+
+// rawColorParams extracts the parameters of a basic SGR color sequence such as
+// Red, shifting foreground codes to background codes when needed
+func rawColorParams(seq string, background bool) string {
+	params := strings.TrimSuffix(strings.TrimPrefix(seq, "\033["), "m")
+	if !background {
+		return params
+	}
+
+	code, err := strconv.Atoi(params)
+	if err != nil {
+		return params
+	}
+
+	if (code >= fgBase && code < fgBase+brightDistance) ||
+		(code >= fgBrightBase && code < fgBrightBase+brightDistance) {
+		code += bgOffset
+	}
+
+	return strconv.Itoa(code)
+}
+
+// parseHex parses "rgb" or "rrggbb"
+func parseHex(hex string) (r, g, b uint8, ok bool) {
+	switch len(hex) {
+	case hexShortLen:
+		v, err := strconv.ParseUint(hex, 16, 16)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+
+		return uint8(v>>8&0xf) * hexNibble, uint8(v>>4&0xf) * hexNibble, uint8(v&0xf) * hexNibble, true
+	case hexLongLen:
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+
+		return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// ansiPalette returns the RGB values xterm uses for the 16 basic colors
+func ansiPalette() [basicColors][3]uint8 {
+	return [basicColors][3]uint8{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+}
+
+// cubeLevel returns the channel value of a color cube coordinate
+func cubeLevel(i int) uint8 {
+	if i == 0 {
+		return 0
+	}
+
+	return uint8(55 + i*40) //nolint:mnd // xterm color cube formula
+}
+
+// paletteRGB returns the RGB value of an xterm-256 palette index
+func paletteRGB(index int) (r, g, b uint8) {
+	switch {
+	case index < basicColors:
+		c := ansiPalette()[index]
+		return c[0], c[1], c[2]
+	case index < grayOffset:
+		i := index - cubeOffset
+		return cubeLevel(i / (cubeSize * cubeSize)), cubeLevel(i / cubeSize % cubeSize), cubeLevel(i % cubeSize)
+	default:
+		v := uint8(grayBase + (index-grayOffset)*grayStep)
+		return v, v, v
+	}
+}
+
+// rgbTo256 returns the xterm-256 palette index closest to an RGB color,
+// choosing between the color cube and the grayscale ramp
+func rgbTo256(r, g, b uint8) int {
+	toCube := func(v uint8) int {
+		if v < 48 { //nolint:mnd // midpoint between the first two cube levels
+			return 0
+		}
+
+		return min((int(v)-35)/40, cubeSize-1) //nolint:mnd // inverse of cubeLevel
+	}
+
+	cr, cg, cb := toCube(r), toCube(g), toCube(b)
+	cube := cubeOffset + cr*cubeSize*cubeSize + cg*cubeSize + cb
+	cubeDist := colorDistance(r, g, b, cubeLevel(cr), cubeLevel(cg), cubeLevel(cb))
+
+	avg := (int(r) + int(g) + int(b)) / 3 //nolint:mnd // three channels
+	grayIndex := min(max((avg-grayBase+grayStep/2)/grayStep, 0), grayLevels-1)
+	gray := uint8(grayBase + grayIndex*grayStep)
+
+	if colorDistance(r, g, b, gray, gray, gray) < cubeDist {
+		return grayOffset + grayIndex
+	}
+
+	return cube
+}
+
+// rgbTo16 returns the basic ANSI color closest to an RGB color
+func rgbTo16(r, g, b uint8) int {
+	best, bestDist := 0, -1
+
+	for i, c := range ansiPalette() {
+		if d := colorDistance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return best
+}
+
+// colorDistance returns the squared, perceptually weighted distance between two colors
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr := int(r1) - int(r2)
+	dg := int(g1) - int(g2)
+	db := int(b1) - int(b2)
+
+	return 2*dr*dr + 4*dg*dg + 3*db*db //nolint:mnd // weights approximate luminance
+}
@@ -0,0 +1,74 @@
+package termfmt
+
+import (
+	"testing"
+)
+
+func TestColorSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		color Color
+		level ColorLevel
+		want  string
+	}{
+		{"constant passthrough", Red, ColorLevel16, Red},
+		{"disabled", Red, ColorLevelNone, ""},
+		{"name", "bright-blue", ColorLevel16, BrightBlue},
+		{"truecolor", "#ff8800", ColorLevelTrueColor, "\033[38;2;255;136;0m"},
+		{"rgb to 256", "#ff8800", ColorLevel256, "\033[38;5;208m"},
+		{"rgb to 16", "#ff0000", ColorLevel16, BrightRed},
+		{"256 passthrough", ANSI256(208), ColorLevel256, "\033[38;5;208m"},
+		{"256 to 16", ANSI256(196), ColorLevel16, BrightRed},
+		{"gray ramp", "#808080", ColorLevel256, "\033[38;5;244m"},
+		{"basic index", ANSI256(2), ColorLevel256, Green},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.Sequence(tt.level); got != tt.want {
+				t.Errorf("Color(%q).Sequence(%s) = %q, want %q", tt.color, tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorBackgroundSequence(t *testing.T) {
+	if got := Color(Blue).BackgroundSequence(ColorLevel16); got != BgBlue {
+		t.Errorf("BackgroundSequence() = %q, want %q", got, BgBlue)
+	}
+
+	if got := RGB(0, 0, 0).BackgroundSequence(ColorLevelTrueColor); got != "\033[48;2;0;0;0m" {
+		t.Errorf("BackgroundSequence() = %q", got)
+	}
+}
+
+func TestHex(t *testing.T) {
+	c, err := Hex("#f80")
+	if err != nil || c != "#ff8800" {
+		t.Errorf("Hex(#f80) = %q, %v", c, err)
+	}
+
+	if _, err := Hex("#zzzzzz"); err == nil {
+		t.Error("Hex() accepted an invalid color")
+	}
+}
+
+func TestColorLevelFromTerm(t *testing.T) {
+	tests := []struct {
+		colorTerm, term string
+		want            ColorLevel
+	}{
+		{"truecolor", "xterm", ColorLevelTrueColor},
+		{"", "xterm-256color", ColorLevel256},
+		{"", "xterm", ColorLevel16},
+		{"", "dumb", ColorLevelNone},
+	}
+
+	for _, tt := range tests {
+		env := MapEnv(map[string]string{"COLORTERM": tt.colorTerm, "TERM": tt.term})
+
+		if got := ColorLevelFromEnv(env); got != tt.want {
+			t.Errorf("ColorLevelFromEnv() with COLORTERM=%q TERM=%q = %s, want %s", tt.colorTerm, tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package termfmt
+
+import "strings"
+
+// Renderable is a component that renders itself into the space described by
+// a RenderContext. Components can be nested in containers such as VStack and
+// HStack and rendered again at a different width.
+type Renderable interface {
+	Render(ctx RenderContext) string
+}
+
+// RenderContext describes where a component is rendered
+type RenderContext struct {
+	Width   int              // Cells available to the component, 0 for unbounded
+	Options *TerminalOptions // Color, emoji, theme and capability settings
+}
+
+// NewRenderContext returns a context spanning opts.Width
+func NewRenderContext(opts *TerminalOptions) RenderContext {
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	return RenderContext{Width: opts.Width, Options: opts}
+}
+
+// WithWidth returns a copy of the context with a different width
+func (ctx RenderContext) WithWidth(width int) RenderContext {
+	ctx.Width = width
+	return ctx
+}
+
+// Theme returns the theme configured in the context's options
+func (ctx RenderContext) Theme() *Theme {
+	return themeOf(ctx.Options)
+}
+
+// options returns a copy of the context's options with Width set to ctx.Width
+func (ctx RenderContext) options() *TerminalOptions {
+	opts := defaultOptions()
+	if ctx.Options != nil {
+		copied := *ctx.Options
+		opts = &copied
+	}
+
+	opts.Width = ctx.Width
+
+	return opts
+}
+
+// Render renders r at opts.Width
+func Render(r Renderable, opts *TerminalOptions) string {
+	return r.Render(NewRenderContext(opts))
+}
+
+// RenderFunc adapts a function to the Renderable interface
+type RenderFunc func(ctx RenderContext) string
+
+// Render calls f(ctx)
+func (f RenderFunc) Render(ctx RenderContext) string {
+	return f(ctx)
+}
+
+// Text is plain text wrapped to the available width
+type Text string
+
+// Render wraps the text to ctx.Width
+func (t Text) Render(ctx RenderContext) string {
+	return strings.Join(wrapLines(string(t), ctx.Width), "\n")
+}
+
+// BoxComponent draws a box around a child component, see RenderBox
+type BoxComponent struct {
+	Title  string
+	Child  Renderable
+	Layout *BoxOptions // Nil for the layout used by Box
+}
+
+// Render renders the child at the width left inside the box, then boxes it
+func (c BoxComponent) Render(ctx RenderContext) string {
+	layout := c.Layout
+	if layout == nil {
+		layout = DefaultBoxOptions()
+		layout.TitleRow = true
+	}
+
+	content := ""
+	if c.Child != nil {
+		inner := ctx.Width
+		if inner > 0 {
+			chrome := borderCells + layout.Padding.Left + layout.Padding.Right + layout.Margin.Left + layout.Margin.Right
+			inner = max(inner-chrome, 1)
+		}
+
+		content = c.Child.Render(ctx.WithWidth(inner))
+	}
+
+	return RenderBox(c.Title, content, layout, ctx.options())
+}
+
+// TableComponent renders a table, see TableWithOptions
+type TableComponent struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Render renders the table within ctx.Width
+func (c TableComponent) Render(ctx RenderContext) string {
+	return TableWithOptions(c.Headers, c.Rows, ctx.options())
+}
+
+// BarChartComponent renders a bar chart, see BarChartWithOptions and BarChartPairs
+type BarChartComponent struct {
+	Data map[string]int
+	Bars []Bar // Bars drawn after those of Data, in the order given by default
+}
+
+// Render renders the chart across ctx.Width
+func (c BarChartComponent) Render(ctx RenderContext) string {
+	return BarChartPairs(append(barEntries(c.Data), c.Bars...), 0, ctx.options())
+}
+
+// TreeComponent renders a tree view, see TreeViewWithOptions
+type TreeComponent struct {
+	Items []TreeItem
+}
+
+// Render renders the tree within ctx.Width
+func (c TreeComponent) Render(ctx RenderContext) string {
+	return TreeViewWithOptions(c.Items, ctx.options())
+}
+
+// ProgressBarComponent renders a progress bar, see ProgressBarWithOptions
+type ProgressBarComponent struct {
+	Current int
+	Total   int
+}
+
+// Render renders the progress bar across ctx.Width
+func (c ProgressBarComponent) Render(ctx RenderContext) string {
+	width := ctx.Width
+	if width <= 0 {
+		width = DefaultTerminalWidth
+	}
+
+	return ProgressBarWithOptions(c.Current, c.Total, width, ctx.options())
+}
+
+// SummaryComponent renders a summary box, see Summary and SummaryPairs
+type SummaryComponent struct {
+	Title string
+	Items map[string]interface{}
+	Pairs []KeyValue // Items shown after those of Items, in the order given by default
+}
+
+// Render renders the summary within ctx.Width
+func (c SummaryComponent) Render(ctx RenderContext) string {
+	return SummaryPairs(c.Title, append(mapEntries(c.Items), c.Pairs...), ctx.options())
+}
+
+// VStack renders its children one below the other at the full width
+type VStack struct {
+	Children []Renderable
+	Gap      int       // Blank lines between children
+	Align    Alignment // Horizontal alignment of narrower children
+}
+
+// Render renders every child at ctx.Width and stacks the results
+func (s VStack) Render(ctx RenderContext) string {
+	blocks := make([]string, 0, len(s.Children))
+	for _, child := range s.Children {
+		blocks = append(blocks, child.Render(ctx))
+	}
+
+	return JoinVertical(s.Align, s.Gap, blocks...)
+}
+
+// HStack renders its children side by side. The width is shared according
+// to Columns, one per child, or equally when Columns is empty. Like Grid,
+// children flow onto new rows when they do not fit.
+type HStack struct {
+	Children []Renderable
+	Columns  []GridColumn
+	Gap      int               // Columns between children
+	Align    VerticalAlignment // Vertical alignment of shorter children
+}
+
+// Render lays out the children in a Grid across ctx.Width
+func (s HStack) Render(ctx RenderContext) string {
+	columns := s.Columns
+	if len(columns) == 0 {
+		columns = make([]GridColumn, len(s.Children))
+	}
+
+	cells := make([]GridCell, 0, len(s.Children))
+	for _, child := range s.Children {
+		cells = append(cells, func(opts *TerminalOptions) string {
+			return child.Render(ctx.WithWidth(opts.Width))
+		})
+	}
+
+	return Grid{Columns: columns, Gap: s.Gap, Align: s.Align}.Render(cells, ctx.options())
+}
@@ -0,0 +1,98 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComponentsImplementRenderable(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	components := map[string]Renderable{
+		"box":      BoxComponent{Title: "Status", Child: Text("all services are running normally")},
+		"table":    TableComponent{Headers: []string{"Service", "Description"}, Rows: [][]string{{"api", "serves the public HTTP interface"}}},
+		"barchart": BarChartComponent{Data: map[string]int{"errors": 3, "warnings": 9}},
+		"tree":     TreeComponent{Items: []TreeItem{{Label: "root", Value: "a value long enough to be truncated at narrow widths"}}},
+		"progress": ProgressBarComponent{Current: 3, Total: 4},
+		"summary":  SummaryComponent{Title: "Totals", Items: map[string]interface{}{"files": 12}},
+	}
+
+	for name, c := range components {
+		for _, width := range []int{30, 60} {
+			out := c.Render(NewRenderContext(opts).WithWidth(width))
+			if out == "" {
+				t.Errorf("%s rendered nothing at width %d", name, width)
+			}
+
+			for _, line := range strings.Split(out, "\n") {
+				if StringWidth(line) > width {
+					t.Errorf("%s line exceeds width %d: %q", name, width, line)
+				}
+			}
+		}
+	}
+}
+
+func TestProgressBarComponentNarrow(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	bar := ProgressBarComponent{Current: 3, Total: 4}
+
+	for _, width := range []int{1, 15, 20} {
+		if out := bar.Render(NewRenderContext(opts).WithWidth(width)); !contains(out, "75.0%") {
+			t.Errorf("progress bar at width %d = %q", width, out)
+		}
+	}
+
+	opts.Width = 30
+
+	out := Render(HStack{Gap: 2, Children: []Renderable{bar, Text("deploying")}}, opts)
+	if !contains(out, "75.0%") || !contains(out, "deploying") {
+		t.Errorf("progress bar in a 30 column HStack = %q", out)
+	}
+}
+
+func TestStacks(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 40
+
+	doc := VStack{
+		Gap: 1,
+		Children: []Renderable{
+			Text("Dashboard"),
+			HStack{
+				Gap: 2,
+				Children: []Renderable{
+					BoxComponent{Title: "A", Child: Text("left")},
+					BoxComponent{Title: "B", Child: Text("right")},
+				},
+			},
+		},
+	}
+
+	out := Render(doc, opts)
+	lines := strings.Split(out, "\n")
+
+	if !strings.HasPrefix(lines[0], "Dashboard") || lines[1] != strings.Repeat(" ", 40) {
+		t.Errorf("VStack did not stack with a gap:\n%s", out)
+	}
+
+	if !contains(lines[2], "╔") || strings.Count(lines[2], "╔") != 2 {
+		t.Errorf("HStack did not place boxes side by side:\n%s", out)
+	}
+
+	assertUniformWidth(t, "VStack", out)
+}
+
+func TestFormatterAcceptsRenderable(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	out, err := NewTerminalWithOptions(opts).Format(Text("hello"))
+	if err != nil || string(out) != "hello" {
+		t.Errorf("Format(Text) = %q, %v", out, err)
+	}
+}
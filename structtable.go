@@ -0,0 +1,251 @@
+package termfmt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotTabular is returned by TableFromStructs for data that is not a slice
+// of structs or maps
+var ErrNotTabular = errors.New("expected a slice or array of structs or maps")
+
+// structColumn describes a struct field shown as a table column
+type structColumn struct {
+	index  []int
+	header string
+	order  int
+	sorted bool   // order was set in the tag
+	format string // fmt verb from the tag, empty for formatFieldValue
+}
+
+// TableFromStructs renders a slice or array of structs, or of maps with
+// string keys, as a table.
+//
+// Struct columns follow the field order. Headers come from the `termfmt` tag,
+// then the `json` tag, then the field name. The termfmt tag also accepts
+// options after the name:
+//
+//	type Service struct {
+//		Name   string  `termfmt:"Service,order=1"`
+//		CPU    float64 `termfmt:"CPU %,format=%.1f"`
+//		Secret string  `termfmt:",omit"` // or `termfmt:"-"`
+//	}
+//
+// Columns with order=N come first in ascending order, followed by the others.
+// Values are formatted like struct fields in Formatter output unless the tag
+// sets a fmt format, which must be the last option and may contain commas.
+// Map keys become columns in sorted order.
+func TableFromStructs(data interface{}, opts *TerminalOptions) (string, error) {
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("%w, got %T", ErrNotTabular, data)
+	}
+
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	switch {
+	case elem.Kind() == reflect.Struct:
+		headers, rows := structRows(v, elem, opts)
+		return TableWithOptions(headers, rows, opts), nil
+	case elem.Kind() == reflect.Map && elem.Key().Kind() == reflect.String,
+		elem.Kind() == reflect.Interface:
+		headers, rows, err := mapRows(v, opts)
+		if err != nil {
+			return "", err
+		}
+
+		return TableWithOptions(headers, rows, opts), nil
+	default:
+		return "", fmt.Errorf("%w, got %T", ErrNotTabular, data)
+	}
+}
+
+// structRows converts a slice of structs of type t into headers and rows
+func structRows(v reflect.Value, t reflect.Type, opts *TerminalOptions) ([]string, [][]string) {
+	columns := structColumns(t)
+
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+
+	rows := make([][]string, 0, v.Len())
+
+	for i := range v.Len() {
+		item := v.Index(i)
+		for item.Kind() == reflect.Ptr && !item.IsNil() {
+			item = item.Elem()
+		}
+
+		row := make([]string, len(columns))
+
+		if item.Kind() == reflect.Struct {
+			for j, col := range columns {
+				row[j] = col.value(item.FieldByIndex(col.index), opts)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return headers, rows
+}
+
+// structColumns returns the columns for the exported fields of t
+func structColumns(t reflect.Type) []structColumn {
+	columns := make([]structColumn, 0, t.NumField())
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		col, ok := parseStructTag(field)
+		if !ok {
+			continue
+		}
+
+		col.index = field.Index
+		columns = append(columns, col)
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		if columns[i].sorted != columns[j].sorted {
+			return columns[i].sorted
+		}
+
+		return columns[i].order < columns[j].order
+	})
+
+	return columns
+}
+
+// parseStructTag reads the column settings of a field, reporting false when
+// the field is omitted
+func parseStructTag(field reflect.StructField) (structColumn, bool) {
+	col := structColumn{header: field.Name}
+
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name == "-" {
+		return col, false
+	} else if name != "" {
+		col.header = name
+	}
+
+	tag, ok := field.Tag.Lookup("termfmt")
+	if !ok {
+		return col, true
+	}
+
+	// The format comes last and runs to the end of the tag, so it may contain commas
+	tag, format, hasFormat := strings.Cut(tag, ",format=")
+	if hasFormat {
+		col.format = format
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return col, false
+	}
+
+	if parts[0] != "" {
+		col.header = parts[0]
+	}
+
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(option, "=")
+
+		switch key {
+		case "omit":
+			return col, false
+		case "order":
+			if n, err := strconv.Atoi(value); err == nil {
+				col.order, col.sorted = n, true
+			}
+		}
+	}
+
+	return col, true
+}
+
+// value formats a field of the column
+func (c structColumn) value(v reflect.Value, opts *TerminalOptions) string {
+	if c.format != "" && v.CanInterface() {
+		return fmt.Sprintf(c.format, v.Interface())
+	}
+
+	return formatFieldValue(v, opts)
+}
+
+// mapRows converts a slice of maps into headers, the sorted union of their
+// keys, and rows
+func mapRows(v reflect.Value, opts *TerminalOptions) ([]string, [][]string, error) {
+	items := make([]reflect.Value, 0, v.Len())
+	seen := make(map[string]bool)
+
+	var headers []string
+
+	for i := range v.Len() {
+		item := v.Index(i)
+		for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+			item = item.Elem()
+		}
+
+		if item.Kind() != reflect.Map || item.Type().Key().Kind() != reflect.String {
+			return nil, nil, fmt.Errorf("%w, got %s at index %d", ErrNotTabular, item.Kind(), i)
+		}
+
+		for _, key := range item.MapKeys() {
+			if !seen[key.String()] {
+				seen[key.String()] = true
+				headers = append(headers, key.String())
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Strings(headers)
+
+	rows := make([][]string, 0, len(items))
+
+	for _, item := range items {
+		row := make([]string, len(headers))
+
+		for j, header := range headers {
+			value := item.MapIndex(reflect.ValueOf(header).Convert(item.Type().Key()))
+			if !value.IsValid() {
+				continue
+			}
+
+			if value.Kind() == reflect.Interface {
+				value = value.Elem()
+			}
+
+			if value.IsValid() {
+				row[j] = formatFieldValue(value, opts)
+			} else {
+				row[j] = Muted("nil", opts)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return headers, rows, nil
+}
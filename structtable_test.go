@@ -0,0 +1,95 @@
+package termfmt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type tableService struct {
+	Name     string  `json:"name"`
+	CPU      float64 `termfmt:"CPU %,order=1,format=%.1f"`
+	Replicas int     `termfmt:"Replicas"`
+	Healthy  bool
+	Token    string `termfmt:"-"`
+	Internal string `termfmt:",omit"`
+	private  string
+}
+
+func TestTableFromStructs(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	services := []*tableService{
+		{Name: "api", CPU: 45.25, Replicas: 3, Healthy: true, Token: "secret", private: "x"},
+		{Name: "db", CPU: 5, Replicas: 1},
+	}
+
+	table, err := TableFromStructs(services, opts)
+	if err != nil {
+		t.Fatalf("TableFromStructs() error = %v", err)
+	}
+
+	lines := strings.Split(table, "\n")
+	if want := "│ CPU % │ name  │ Replicas │ Healthy │"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+
+	if want := `│ 45.2  │ "api" │ 3        │ true    │`; lines[2] != want {
+		t.Errorf("row = %q, want %q", lines[2], want)
+	}
+
+	if contains(table, "secret") || contains(table, "Internal") {
+		t.Errorf("omitted fields are shown:\n%s", table)
+	}
+}
+
+func TestTableFromStructsFormatWithComma(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	type disk struct {
+		Size int `termfmt:"Size,order=1,format=%d,000 MB"`
+	}
+
+	table, err := TableFromStructs([]disk{{Size: 12}}, opts)
+	if err != nil {
+		t.Fatalf("TableFromStructs() error = %v", err)
+	}
+
+	if lines := strings.Split(table, "\n"); lines[2] != "│ 12,000 MB │" {
+		t.Errorf("row = %q, want the whole format applied", lines[2])
+	}
+}
+
+func TestTableFromMaps(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	rows := []map[string]interface{}{
+		{"name": "api", "port": 8080},
+		{"name": "db", "region": "eu"},
+	}
+
+	table, err := TableFromStructs(rows, opts)
+	if err != nil {
+		t.Fatalf("TableFromStructs() error = %v", err)
+	}
+
+	lines := strings.Split(table, "\n")
+	if want := "│ name  │ port │ region │"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+
+	if want := `│ "db"  │      │ "eu"   │`; lines[3] != want {
+		t.Errorf("row = %q, want %q", lines[3], want)
+	}
+}
+
+func TestTableFromStructsRejectsOtherData(t *testing.T) {
+	for _, data := range []interface{}{42, []int{1, 2}, tableService{}} {
+		if _, err := TableFromStructs(data, nil); !errors.Is(err, ErrNotTabular) {
+			t.Errorf("TableFromStructs(%T) error = %v, want ErrNotTabular", data, err)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package termfmt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnderlineStyle selects the shape of an underline
+type UnderlineStyle int
+
+const (
+	// UnderlineNone disables underlining
+	UnderlineNone UnderlineStyle = iota
+	// UnderlineSingle draws a single straight line
+	UnderlineSingle
+	// UnderlineDouble draws two straight lines
+	UnderlineDouble
+	// UnderlineCurly draws a wavy line, commonly used for spelling errors
+	UnderlineCurly
+	// UnderlineDotted draws a dotted line
+	UnderlineDotted
+	// UnderlineDashed draws a dashed line
+	UnderlineDashed
+)
+
+// textAttr is a set of boolean SGR attributes
+type textAttr uint8
+
+const (
+	attrBold textAttr = 1 << iota
+	attrFaint
+	attrItalic
+	attrBlink
+	attrReverse
+	attrStrikethrough
+)
+
+// attrCodes returns the SGR parameter for every boolean attribute, in output order
+func attrCodes() []struct {
+	attr textAttr
+	code string
+} {
+	return []struct {
+		attr textAttr
+		code string
+	}{
+		{attrBold, "1"},
+		{attrFaint, "2"},
+		{attrItalic, "3"},
+		{attrBlink, "5"},
+		{attrReverse, "7"},
+		{attrStrikethrough, "9"},
+	}
+}
+
+// Style describes how text is rendered: foreground and background colors plus
+// text attributes. Setters return a modified copy, so styles can be chained
+// and shared safely. The zero value renders text unchanged.
+//
+//	warn := termfmt.NewStyle().Foreground("#ffaf00").Bold(true)
+//	fmt.Println(warn.Render("disk almost full"))
+type Style struct {
+	fg        Color
+	bg        Color
+	attrs     textAttr
+	underline UnderlineStyle
+	level     ColorLevel
+	levelSet  bool
+}
+
+// NewStyle returns an empty style
+func NewStyle() Style {
+	return Style{}
+}
+
+// Foreground sets the text color
+func (s Style) Foreground(c Color) Style {
+	s.fg = c
+	return s
+}
+
+// Background sets the background color
+func (s Style) Background(c Color) Style {
+	s.bg = c
+	return s
+}
+
+// Bold sets or clears bold text
+func (s Style) Bold(v bool) Style {
+	return s.setAttr(attrBold, v)
+}
+
+// Faint sets or clears faint (dimmed) text
+func (s Style) Faint(v bool) Style {
+	return s.setAttr(attrFaint, v)
+}
+
+// Italic sets or clears italic text
+func (s Style) Italic(v bool) Style {
+	return s.setAttr(attrItalic, v)
+}
+
+// Blink sets or clears blinking text
+func (s Style) Blink(v bool) Style {
+	return s.setAttr(attrBlink, v)
+}
+
+// Reverse sets or clears swapped foreground and background colors
+func (s Style) Reverse(v bool) Style {
+	return s.setAttr(attrReverse, v)
+}
+
+// Strikethrough sets or clears crossed-out text
+func (s Style) Strikethrough(v bool) Style {
+	return s.setAttr(attrStrikethrough, v)
+}
+
+// Underline sets or clears a single underline
+func (s Style) Underline(v bool) Style {
+	if v {
+		s.underline = UnderlineSingle
+	} else {
+		s.underline = UnderlineNone
+	}
+
+	return s
+}
+
+// UnderlineStyle sets the underline shape. Terminals without support for
+// double, curly, dotted or dashed underlines show a single underline.
+func (s Style) UnderlineStyle(u UnderlineStyle) Style {
+	s.underline = u
+	return s
+}
+
+// ColorLevel sets the color level Render uses instead of the one detected
+// once for the process. ColorLevelNone renders text without escapes.
+func (s Style) ColorLevel(level ColorLevel) Style {
+	s.level = level
+	s.levelSet = true
+
+	return s
+}
+
+// IsZero reports whether the style has no colors or attributes
+func (s Style) IsZero() bool {
+	return s.fg == NoColor && s.bg == NoColor && s.attrs == 0 && s.underline == UnderlineNone
+}
+
+// Render applies the style to text at the style's ColorLevel, or at the
+// level detected once for the process like functions called without
+// options, so NO_COLOR is honored. Each line is styled separately so that
+// the result can be placed inside boxes and tables.
+func (s Style) Render(text string) string {
+	level := s.level
+	if !s.levelSet {
+		level = processCapabilities().ColorLevel
+	}
+
+	return s.renderAt(text, level)
+}
+
+// RenderWithOptions applies the style to text if color is enabled in opts.
+// Nil opts uses the default options.
+func (s Style) RenderWithOptions(text string, opts *TerminalOptions) string {
+	if s.IsZero() {
+		return text
+	}
+
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	level := activeColorLevel(opts)
+	if s.levelSet {
+		level = min(level, s.level)
+	}
+
+	return s.renderAt(text, level)
+}
+
+// renderAt applies the style to every non-empty line of text at the given level
+func (s Style) renderAt(text string, level ColorLevel) string {
+	seq := s.sequence(level)
+	if seq == "" || text == "" {
+		return text
+	}
+
+	// Styles inside the text end with Reset, after which the style is
+	// opened again so that it covers the whole line
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = seq + strings.ReplaceAll(line, Reset, Reset+seq) + Reset
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// sequence returns a single SGR sequence that enables the style
+func (s Style) sequence(level ColorLevel) string {
+	if level == ColorLevelNone {
+		return ""
+	}
+
+	params := make([]string, 0, len(attrCodes())+3) //nolint:mnd // underline and two colors
+
+	for _, ac := range attrCodes() {
+		if s.attrs&ac.attr != 0 {
+			params = append(params, ac.code)
+		}
+	}
+
+	switch s.underline {
+	case UnderlineNone:
+	case UnderlineSingle:
+		params = append(params, "4")
+	default:
+		params = append(params, fmt.Sprintf("4:%d", s.underline))
+	}
+
+	if p := s.fg.params(level, false); p != "" {
+		params = append(params, p)
+	}
+
+	if p := s.bg.params(level, true); p != "" {
+		params = append(params, p)
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// setAttr returns a copy of s with attr set or cleared
+func (s Style) setAttr(attr textAttr, v bool) Style {
+	if v {
+		s.attrs |= attr
+	} else {
+		s.attrs &^= attr
+	}
+
+	return s
+}
+
+// ParseStyle builds a style from a space- or comma-separated list of
+// attribute and color names, such as "bold red" or "underline #ff8800 bg:blue".
+// Colors prefixed with "bg:" or "bg-" set the background. Attributes are bold,
+// faint (or dim), italic, underline, double-underline, curly-underline,
+// dotted-underline, dashed-underline, blink, reverse and strikethrough.
+func ParseStyle(spec string) (Style, error) {
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	return parseStyleFields(fields)
+}
+
+// parseStyleFields applies every known field to a new style and reports the
+// first unknown one
+func parseStyleFields(fields []string) (Style, error) {
+	var (
+		s   Style
+		err error
+	)
+
+	for _, field := range fields {
+		next, ok := applyStyleField(s, strings.ToLower(field))
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("unknown style %q", field)
+			}
+
+			continue
+		}
+
+		s = next
+	}
+
+	return s, err
+}
+
+// applyStyleField applies a single attribute or color name to s
+func applyStyleField(s Style, field string) (Style, bool) {
+	switch field {
+	case "bold":
+		return s.Bold(true), true
+	case "faint", "dim":
+		return s.Faint(true), true
+	case "italic":
+		return s.Italic(true), true
+	case "underline":
+		return s.Underline(true), true
+	case "double-underline":
+		return s.UnderlineStyle(UnderlineDouble), true
+	case "curly-underline":
+		return s.UnderlineStyle(UnderlineCurly), true
+	case "dotted-underline":
+		return s.UnderlineStyle(UnderlineDotted), true
+	case "dashed-underline":
+		return s.UnderlineStyle(UnderlineDashed), true
+	case "blink":
+		return s.Blink(true), true
+	case "reverse":
+		return s.Reverse(true), true
+	case "strikethrough", "strike":
+		return s.Strikethrough(true), true
+	}
+
+	for _, prefix := range []string{"bg:", "bg-"} {
+		if name, ok := strings.CutPrefix(field, prefix); ok {
+			c := Color(name)
+			return s.Background(c), c.valid()
+		}
+	}
+
+	c := Color(strings.TrimPrefix(field, "fg:"))
+
+	return s.Foreground(c), c.valid()
+}
+
+// valid reports whether c can be rendered
+func (c Color) valid() bool {
+	if strings.HasPrefix(string(c), "\033[") {
+		return true
+	}
+
+	if _, ok := c.paletteIndex(); ok {
+		return true
+	}
+
+	hex, isHex := strings.CutPrefix(string(c), "#")
+	_, _, _, ok := parseHex(hex)
+
+	return isHex && ok
+}
@@ -0,0 +1,72 @@
+package termfmt
+
+import (
+	"testing"
+)
+
+func TestStyleRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"zero", NewStyle(), "text"},
+		{"bold red", NewStyle().Bold(true).Foreground(Red), "\033[1;31mtext\033[0m"},
+		{"background", NewStyle().Background("blue"), "\033[44mtext\033[0m"},
+		{"curly underline", NewStyle().UnderlineStyle(UnderlineCurly), "\033[4:3mtext\033[0m"},
+		{"attributes", NewStyle().Faint(true).Reverse(true).Strikethrough(true), "\033[2;7;9mtext\033[0m"},
+		{"cleared", NewStyle().Bold(true).Bold(false), "text"},
+		{"downsampled", NewStyle().Foreground("#ff8800").ColorLevel(ColorLevel256), "\033[38;5;208mtext\033[0m"},
+		{"disabled", NewStyle().Bold(true).ColorLevel(ColorLevelNone), "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := tt.style
+			if !style.levelSet {
+				style = style.ColorLevel(ColorLevelTrueColor)
+			}
+
+			if got := style.Render("text"); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyleRenderMultiline(t *testing.T) {
+	got := NewStyle().Bold(true).ColorLevel(ColorLevel16).Render("a\n\nb")
+	if got != "\033[1ma\033[0m\n\n\033[1mb\033[0m" {
+		t.Errorf("Render() = %q", got)
+	}
+}
+
+func TestStyleRenderWithNilOptions(t *testing.T) {
+	style := NewStyle().Bold(true).ColorLevel(ColorLevel16)
+	if got, want := style.RenderWithOptions("text", nil), style.RenderWithOptions("text", defaultOptions()); got != want {
+		t.Errorf("RenderWithOptions(nil) = %q, want %q as with the default options", got, want)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := ParseStyle("bold, underline #ff8800 bg:bright-black")
+	if err != nil {
+		t.Fatalf("ParseStyle() error = %v", err)
+	}
+
+	want := NewStyle().Bold(true).Underline(true).Foreground("#ff8800").Background("bright-black")
+	if style != want {
+		t.Errorf("ParseStyle() = %+v, want %+v", style, want)
+	}
+
+	if _, err := ParseStyle("bold sparkly"); err == nil {
+		t.Error("ParseStyle() accepted an unknown name")
+	}
+}
+
+func TestStylizeBackgroundColors(t *testing.T) {
+	got := Stylize("text", []string{"bold", "bg-red", "unknown"}, colorOptions())
+	if got != "\033[1;41mtext\033[0m" {
+		t.Errorf("Stylize() = %q", got)
+	}
+}
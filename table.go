@@ -0,0 +1,493 @@
+package termfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRaggedRow is returned by TableStrict when a row and the headers differ in length
+var ErrRaggedRow = errors.New("table row length does not match headers")
+
+// TableColumn configures one column of a table rendered by TableWithColumns
+type TableColumn struct {
+	Header string
+	Align  Alignment // Alignment of header and cells, AlignDecimal lines up numbers
+
+	// Group is drawn in a header row above the column headers, spanning
+	// adjacent columns with the same Group
+	Group string
+
+	MinWidth int // Narrowest width, also when shrinking to fit opts.Width
+	MaxWidth int // Widest width, 0 for no limit
+
+	// Priority orders columns when the table is shrunk to fit opts.Width.
+	// Columns with a lower priority are shrunk, and hidden when Optional,
+	// before those with a higher one.
+	Priority int
+
+	// Optional columns are hidden when the table does not fit opts.Width
+	// without wrapping headers. The record layout still shows them.
+	Optional bool
+
+	// Truncate cuts cells wider than the column with an ellipsis instead of
+	// wrapping them onto several lines
+	Truncate bool
+
+	HeaderStyle Style // Style for the header cell, zero for the theme's TableHeader
+
+	// Format converts a cell before it is measured and drawn, for example to
+	// add units or color
+	Format func(cell string) string
+}
+
+// Table creates a formatted table from headers and rows
+func Table(headers []string, rows [][]string) string {
+	return TableWithOptions(headers, rows, defaultOptions())
+}
+
+// TableWithOptions creates a formatted table with custom options.
+// When the table is wider than opts.Width the widest columns are shrunk and
+// their cells wrapped onto several lines. With opts.TableRecords set, tables
+// that still do not fit are drawn as one block of header and value lines per
+// row instead. Borders use opts.Border, or LightBorder when it is nil.
+//
+// Rows shorter than headers are padded with empty cells and cells beyond the
+// last header are shown in extra columns without a header. Cells containing
+// newlines span several lines of their row. Use TableStrict to reject rows
+// whose length differs from the headers.
+func TableWithOptions(headers []string, rows [][]string, opts *TerminalOptions) string {
+	columns := make([]TableColumn, len(headers))
+	for i, header := range headers {
+		columns[i] = TableColumn{Header: header}
+	}
+
+	return TableWithColumns(columns, rows, opts)
+}
+
+// TableStrict creates a table like TableWithOptions but returns an error
+// wrapping ErrRaggedRow when a row has more or fewer cells than headers
+func TableStrict(headers []string, rows [][]string, opts *TerminalOptions) (string, error) {
+	for i, row := range rows {
+		if len(row) != len(headers) {
+			return "", fmt.Errorf("%w: row %d has %d cells, want %d", ErrRaggedRow, i, len(row), len(headers))
+		}
+	}
+
+	return TableWithOptions(headers, rows, opts), nil
+}
+
+// TableWithColumns creates a table with per-column alignment, width limits,
+// overflow handling, header styles and cell formatting.
+//
+//	columns := []termfmt.TableColumn{
+//		{Header: "Service"},
+//		{Header: "CPU", Align: termfmt.AlignDecimal, Format: func(c string) string { return c + "%" }},
+//		{Header: "Notes", MaxWidth: 30, Truncate: true},
+//	}
+func TableWithColumns(columns []TableColumn, rows [][]string, opts *TerminalOptions) string {
+	if len(columns) == 0 {
+		return ""
+	}
+
+	return newTableLayout(columns, nil, plainRows(rows), nil, opts).render()
+}
+
+// rowKind distinguishes data rows from rows added by grouping
+type rowKind int
+
+const (
+	rowData     rowKind = iota // A data row
+	rowGroup                   // A group header or section spanning every column
+	rowSubtotal                // A group's aggregates, see TableBuilder.GroupBy
+)
+
+// tableRow is one row of a table. Slots covered by a cell spanning from the
+// left or from above hold an empty cell with a zero span.
+type tableRow struct {
+	cells []string
+	spans []cellSpan // Span of each cell, nil when no cell spans
+	kind  rowKind
+}
+
+// plainRows returns rows of data cells that do not span
+func plainRows(rows [][]string) []tableRow {
+	plain := make([]tableRow, len(rows))
+	for i, row := range rows {
+		plain[i] = tableRow{cells: row}
+	}
+
+	return plain
+}
+
+// tableLayout holds the formatted cells and measured column widths of a table
+type tableLayout struct {
+	columns []TableColumn
+	header  []tableRow // Header rows, the column headers last
+	rows    []tableRow
+	footers []tableRow
+	widths  []int
+	border  BorderStyle
+	opts    *TerminalOptions
+
+	frame         bool  // Draw top and bottom borders
+	rowSeparators bool  // Draw a separator between data rows
+	headerStyle   Style // Header style overriding the theme, zero for TableHeader
+	footerStyle   Style // Style for footer cells and group headers
+	subtotalStyle Style // Style for group subtotal cells
+	zebra         Style // Style for every second data row
+	records       bool  // Draw each row as a block of header and value lines
+
+	kept []int // Original index of each column, nil when none were hidden
+}
+
+// newTableLayout formats the cells and computes column widths that fit
+// opts.Width. headers are drawn above the column headers.
+func newTableLayout(columns []TableColumn, headers, rows, footers []tableRow, opts *TerminalOptions) *tableLayout {
+	// Footers are formatted with the rows so that totals line up with them
+	formatted := formatTableCells(columns, append(rows[:len(rows):len(rows)], footers...))
+
+	// Cells beyond the last column get columns without a header
+	cells := len(columns)
+	for _, row := range append(formatted[:len(formatted):len(formatted)], headers...) {
+		cells = max(cells, len(row.cells))
+	}
+
+	columns = append(columns[:len(columns):len(columns)], make([]TableColumn, cells-len(columns))...)
+
+	t := &tableLayout{
+		columns: columns,
+		rows:    formatted[:len(rows)],
+		footers: formatted[len(rows):],
+		widths:  make([]int, len(columns)),
+		border:  borderOf(opts, LightBorder),
+		opts:    opts,
+	}
+
+	t.header = t.headerRows(headers)
+
+	// Calculate column widths
+	mins := make([]int, len(columns))
+	for i, col := range columns {
+		mins[i] = max(col.MinWidth, minColumnWidth)
+	}
+
+	_, grid := t.grid()
+	t.eachCell(grid, func(cell *spanCell) {
+		if cell.cols == 1 {
+			t.widths[cell.col] = max(t.widths[cell.col], cellWidth(cell.text))
+		}
+	})
+
+	for i, col := range columns {
+		t.widths[i] = max(t.widths[i], col.MinWidth)
+		if col.MaxWidth > 0 {
+			t.widths[i] = min(t.widths[i], max(col.MaxWidth, col.MinWidth))
+		}
+	}
+
+	t.eachCell(grid, t.widenForSpan)
+
+	if opts != nil && opts.Width > 0 {
+		t.fit(mins, opts.Width)
+	}
+
+	return t
+}
+
+// render draws the whole table
+func (t *tableLayout) render() string {
+	if t.records {
+		return t.renderRecords()
+	}
+
+	bs := t.border
+	all, grid := t.grid()
+	heights := t.rowHeights(grid)
+	header := len(t.header)
+
+	var b strings.Builder
+
+	if t.frame {
+		t.writeRule(&b, nil, grid[0], false, bs.Top)
+	}
+
+	t.writeHeader(&b, grid, heights)
+
+	// Separator
+	below := grid[header-1]
+	if len(all) > header {
+		below = grid[header]
+	}
+
+	t.writeRule(&b, grid[header-1], below, false, bs.Top)
+
+	// Data rows, numbered per group for zebra striping
+	stripe := 0
+
+	for i, row := range t.rows {
+		r := header + i
+
+		switch row.kind {
+		case rowGroup:
+			if i > 0 {
+				t.writeRule(&b, grid[r-1], grid[r], true, bs.Top)
+			}
+
+			t.writeRow(&b, grid, heights, r, func(int) Style { return t.footerStyle }, Style{})
+
+			// Open the column dividers again under a row spanning the table
+			if i+1 < len(t.rows) && splits(grid[r+1]) {
+				t.writeRule(&b, grid[r], grid[r+1], true, bs.Top)
+			}
+
+			stripe = 0
+		case rowSubtotal:
+			t.writeRow(&b, grid, heights, r, func(int) Style { return t.subtotalStyle }, Style{})
+		case rowData:
+			if stripe > 0 && t.rowSeparators {
+				t.writeRule(&b, grid[r-1], grid[r], true, bs.Top)
+			}
+
+			rowStyle := Style{}
+			if stripe%2 == 1 {
+				rowStyle = t.zebra
+			}
+
+			t.writeRow(&b, grid, heights, r, nil, rowStyle)
+
+			stripe++
+		}
+	}
+
+	if len(t.footers) > 0 {
+		footer := header + len(t.rows)
+		t.writeRule(&b, grid[footer-1], grid[footer], false, bs.Top)
+
+		for r := footer; r < len(all); r++ {
+			t.writeRow(&b, grid, heights, r, func(int) Style { return t.footerStyle }, Style{})
+		}
+	}
+
+	if t.frame {
+		t.writeRule(&b, grid[len(grid)-1], nil, false, bs.Bottom)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// formatTableCells applies every column's Format function and lines up
+// AlignDecimal columns, returning new rows
+func formatTableCells(columns []TableColumn, rows []tableRow) []tableRow {
+	formatted := make([]tableRow, len(rows))
+	for r, row := range rows {
+		formatted[r] = formatRow(columns, row)
+	}
+
+	for i, col := range columns {
+		if col.Align == AlignDecimal {
+			whole, frac := decimalWidths(formatted, i)
+			alignDecimals(formatted, i, whole, frac)
+		}
+	}
+
+	return formatted
+}
+
+// formatRow applies every column's Format function to the cells of row.
+// Group headers and subtotals, which are built already formatted, and cells
+// spanning several columns are left unchanged.
+func formatRow(columns []TableColumn, row tableRow) tableRow {
+	formatted := tableRow{cells: make([]string, len(row.cells)), spans: row.spans, kind: row.kind}
+
+	for i, cell := range row.cells {
+		if i < len(columns) && columns[i].Format != nil && row.kind == rowData && row.span(i).cols == 1 {
+			cell = columns[i].Format(cell)
+		}
+
+		formatted.cells[i] = cell
+	}
+
+	return formatted
+}
+
+// decimalWidths returns the widest integer and fractional parts in column i
+func decimalWidths(rows []tableRow, i int) (whole, frac int) {
+	for _, row := range rows {
+		if row.aligns(i) {
+			w, f := splitDecimal(row.cells[i])
+			whole = max(whole, StringWidth(w))
+			frac = max(frac, StringWidth(f))
+		}
+	}
+
+	return whole, frac
+}
+
+// alignDecimals pads the cells of column i to the given integer and
+// fractional widths so that their decimal points, or the end of their
+// integer part, line up
+func alignDecimals(rows []tableRow, i, whole, frac int) {
+	for _, row := range rows {
+		if row.aligns(i) {
+			w, f := splitDecimal(row.cells[i])
+			row.cells[i] = alignText(w, whole, AlignRight) + padRight(f, frac)
+		}
+	}
+}
+
+// aligns reports whether cell i of the row takes part in decimal alignment
+func (r tableRow) aligns(i int) bool {
+	return i < len(r.cells) && r.kind != rowGroup && r.span(i).cols == 1
+}
+
+// splitDecimal splits a number at its decimal point, or after its leading
+// digits when it has none, so that "2.1GB" becomes "2" and ".1GB" and
+// "512MB" becomes "512" and "MB". Escape sequences are skipped when looking
+// for the split and kept with the piece they border, so colored cells align.
+func splitDecimal(s string) (whole, frac string) {
+	plain := StripANSI(s)
+
+	n := strings.IndexByte(plain, '.')
+	if n < 0 {
+		n = len(plain) - len(strings.TrimLeft(plain, "+-0123456789,"))
+	}
+
+	for i := 0; i < len(s); {
+		seg := nextSegment(s[i:])
+		if !seg.escape {
+			if n <= 0 {
+				return s[:i], s[i:]
+			}
+
+			n -= len(seg.text)
+		}
+
+		i += len(seg.text)
+	}
+
+	return s, ""
+}
+
+// cellWidth returns the width of the widest line of a cell
+func cellWidth(cell string) int {
+	return blockWidth(strings.Split(cell, "\n"))
+}
+
+// writeHeader writes the header rows with rules between them
+func (t *tableLayout) writeHeader(b *strings.Builder, grid [][]*spanCell, heights []int) {
+	for r := range t.header {
+		if r > 0 {
+			t.writeRule(b, grid[r-1], grid[r], true, t.border.Top)
+		}
+
+		t.writeRow(b, grid, heights, r, t.headerCellStyle, Style{})
+	}
+}
+
+// writeRule writes a horizontal line across the table between the rows of
+// cells upper and lower, either of which is nil at the frame. With join set,
+// cells spanning both rows are left open.
+func (t *tableLayout) writeRule(b *strings.Builder, upper, lower []*spanCell, join bool, fill string) {
+	columns := len(t.widths)
+
+	open := func(c int) bool {
+		return join && upper != nil && lower != nil && upper[c] == lower[c]
+	}
+
+	var rule strings.Builder
+
+	for j := 0; j <= columns; j++ {
+		left := j > 0 && !open(j-1)
+		right := j < columns && !open(j)
+		rule.WriteString(t.junction(divides(upper, j), divides(lower, j), left, right, j == columns, fill))
+
+		if j < columns {
+			segment := fill
+			if open(j) {
+				segment = " "
+			}
+
+			rule.WriteString(strings.Repeat(segment, t.widths[j]+tableRowPadding))
+		}
+	}
+
+	b.WriteString(themeOf(t.opts).TableBorder.RenderWithOptions(rule.String(), t.opts) + "\n")
+}
+
+// writeRow writes the lines of row r, wrapping or truncating cells wider
+// than their columns. Cells spanning several rows continue where the rows
+// above left off. cellStyle, when not nil, styles the text of each cell and
+// rowStyle styles every cell including its padding.
+func (t *tableLayout) writeRow(
+	b *strings.Builder, grid [][]*spanCell, heights []int, r int, cellStyle func(i int) Style, rowStyle Style,
+) {
+	theme := themeOf(t.opts)
+	left := theme.TableBorder.RenderWithOptions(t.border.Left, t.opts)
+	right := theme.TableBorder.RenderWithOptions(t.border.Right, t.opts)
+
+	for line := range heights[r] {
+		b.WriteString(left)
+
+		for c := 0; c < len(t.widths); {
+			cell := grid[r][c]
+
+			offset := line
+			for above := cell.row; above < r; above++ {
+				offset += heights[above]
+			}
+
+			text := ""
+			if offset < len(cell.lines) {
+				text = cell.lines[offset]
+				if cellStyle != nil {
+					text = cellStyle(cell.col).RenderWithOptions(text, t.opts)
+				}
+			}
+
+			width := t.spanWidth(c, cell.cols)
+			c += cell.cols
+
+			side := left
+			if c == len(t.widths) {
+				side = right
+			}
+
+			b.WriteString(rowStyle.RenderWithOptions(" "+alignText(text, width, t.cellAlign(cell))+" ", t.opts) + side)
+		}
+
+		b.WriteString("\n")
+	}
+}
+
+// cellLines splits a cell into lines that fit width, truncating them when
+// column i truncates
+func (t *tableLayout) cellLines(cell string, i, width int) []string {
+	if StringWidth(cell) <= width && !strings.Contains(cell, "\n") {
+		return []string{cell}
+	}
+
+	if !t.columns[i].Truncate {
+		return wrapLines(cell, width)
+	}
+
+	lines := strings.Split(cell, "\n")
+	for j, line := range lines {
+		lines[j] = Truncate(line, width)
+	}
+
+	return lines
+}
+
+// headerCellStyle returns the style for the header cell of column i
+func (t *tableLayout) headerCellStyle(i int) Style {
+	if style := t.columns[i].HeaderStyle; !style.IsZero() {
+		return style
+	}
+
+	if !t.headerStyle.IsZero() {
+		return t.headerStyle
+	}
+
+	return themeOf(t.opts).TableHeader
+}
@@ -0,0 +1,162 @@
+package termfmt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTableColumnAlignment(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := TableWithColumns([]TableColumn{
+		{Header: "Service"},
+		{Header: "CPU", Align: AlignRight},
+		{Header: "Memory", Align: AlignDecimal},
+		{Header: "State", Align: AlignCenter},
+	}, [][]string{
+		{"api", "45%", "512MB", "up"},
+		{"db", "5%", "2.1GB", "down"},
+	}, opts)
+
+	lines := strings.Split(table, "\n")
+	want := []string{
+		"│ Service │ CPU │  Memory │ State │",
+		"├─────────┼─────┼─────────┼───────┤",
+		"│ api     │ 45% │ 512MB   │  up   │",
+		"│ db      │  5% │   2.1GB │ down  │",
+	}
+
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestSplitDecimal(t *testing.T) {
+	tests := []struct{ in, whole, frac string }{
+		{"3.14", "3", ".14"},
+		{"512MB", "512", "MB"},
+		{"-1,024", "-1,024", ""},
+		{"N/A", "", "N/A"},
+		{"\033[32m2.1GB\033[0m", "\033[32m2", ".1GB\033[0m"},
+		{"\033[1m512\033[0mMB", "\033[1m512\033[0m", "MB"},
+	}
+
+	for _, tt := range tests {
+		if whole, frac := splitDecimal(tt.in); whole != tt.whole || frac != tt.frac {
+			t.Errorf("splitDecimal(%q) = %q, %q, want %q, %q", tt.in, whole, frac, tt.whole, tt.frac)
+		}
+	}
+}
+
+func TestTableDecimalAlignmentWithColoredCells(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	green := func(s string) string { return "\033[32m" + s + "\033[0m" }
+
+	table := TableWithColumns([]TableColumn{
+		{Header: "Memory", Align: AlignDecimal, Format: green},
+	}, [][]string{{"512.5"}, {"2.25"}, {"16"}}, opts)
+
+	lines := strings.Split(StripANSI(table), "\n")
+	want := []string{"│ 512.5  │", "│   2.25 │", "│  16    │"}
+
+	for i, line := range want {
+		if lines[i+2] != line {
+			t.Errorf("line %d = %q, want %q", i+2, lines[i+2], line)
+		}
+	}
+
+	assertUniformWidth(t, "TableWithColumns", table)
+}
+
+func TestTableColumnWidthsAndOverflow(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	long := "a description that does not fit"
+	columns := []TableColumn{
+		{Header: "ID", MinWidth: 6},
+		{Header: "Notes", MaxWidth: 12, Truncate: true},
+		{Header: "Wrapped", MaxWidth: 12},
+	}
+
+	table := TableWithColumns(columns, [][]string{{"1", long, long}}, opts)
+	lines := strings.Split(table, "\n")
+
+	if len(lines) != 6 {
+		t.Fatalf("table has %d lines, want header, separator and a wrapped row of 4:\n%s", len(lines), table)
+	}
+
+	if !contains(lines[2], "│ 1      │ a descrip... │") {
+		t.Errorf("MinWidth or Truncate not applied: %q", lines[2])
+	}
+
+	assertUniformWidth(t, "TableWithColumns", table)
+}
+
+func TestTableColumnFormatAndHeaderStyle(t *testing.T) {
+	opts := colorOptions()
+	columns := []TableColumn{
+		{Header: "Load", HeaderStyle: NewStyle().Italic(true), Format: func(c string) string { return c + "%" }},
+	}
+
+	table := TableWithColumns(columns, [][]string{{"42"}}, opts)
+	if !contains(table, "42%") {
+		t.Errorf("Format was not applied:\n%s", table)
+	}
+
+	if !contains(table, "\033[3mLoad") {
+		t.Errorf("HeaderStyle was not applied: %q", table)
+	}
+}
+
+func TestTableRaggedRows(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := TableWithOptions([]string{"A", "B"}, [][]string{{"1"}, {"1", "2", "3"}}, opts)
+	want := strings.Join([]string{
+		"│ A │ B │   │",
+		"├───┼───┼───┤",
+		"│ 1 │   │   │",
+		"│ 1 │ 2 │ 3 │",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("ragged table =\n%s\nwant\n%s", table, want)
+	}
+}
+
+func TestTableStrict(t *testing.T) {
+	_, err := TableStrict([]string{"A", "B"}, [][]string{{"1", "2"}, {"1"}}, colorOptions())
+	if !errors.Is(err, ErrRaggedRow) {
+		t.Errorf("TableStrict() error = %v, want ErrRaggedRow", err)
+	}
+
+	if _, err := TableStrict([]string{"A"}, [][]string{{"1"}}, colorOptions()); err != nil {
+		t.Errorf("TableStrict() error = %v for a well-formed table", err)
+	}
+}
+
+func TestTableMultiLineCells(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := TableWithOptions([]string{"Name", "Notes"}, [][]string{{"api", "first\nsecond line"}, {"db", "x"}}, opts)
+	want := strings.Join([]string{
+		"│ Name │ Notes       │",
+		"├──────┼─────────────┤",
+		"│ api  │ first       │",
+		"│      │ second line │",
+		"│ db   │ x           │",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("multi-line table =\n%s\nwant\n%s", table, want)
+	}
+}
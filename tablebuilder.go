@@ -0,0 +1,172 @@
+package termfmt
+
+// TableBuilder assembles a table step by step. Unlike TableWithOptions it
+// draws a full frame by default, styles the header with the theme, and
+// supports footer rows, row separators and zebra striping. It implements
+// Renderable.
+//
+//	table := termfmt.NewTable("Service", "CPU").
+//		Row("api", "45%").
+//		Row("db", "23%").
+//		Footer("total", "68%").
+//		Border(termfmt.RoundedBorder())
+//	fmt.Println(termfmt.Render(table, opts))
+type TableBuilder struct {
+	columns       []TableColumn
+	headers       []tableRow // Header rows above the column headers
+	rows          []tableRow
+	footers       [][]string
+	border        *BorderStyle
+	frame         bool
+	rowSeparators bool
+	headerStyle   Style
+	zebra         Style
+
+	sortKeys   []SortKey
+	filters    []func(row []string) bool
+	grouped    bool
+	groupBy    int
+	aggregates []Aggregate
+
+	headerPlacer spanPlacer
+	rowPlacer    spanPlacer
+}
+
+// NewTable returns a framed table with the given column headers
+func NewTable(headers ...string) *TableBuilder {
+	columns := make([]TableColumn, len(headers))
+	for i, header := range headers {
+		columns[i] = TableColumn{Header: header}
+	}
+
+	return &TableBuilder{columns: columns, frame: true}
+}
+
+// Columns replaces the columns, see TableColumn
+func (t *TableBuilder) Columns(columns ...TableColumn) *TableBuilder {
+	t.columns = columns
+	return t
+}
+
+// HeaderRow appends a header row drawn above the column headers, such as
+// a group spanning related columns. Header cells spanning down over the
+// column headers replace them.
+//
+//	table := termfmt.NewTable("Host", "Used", "Free").
+//		HeaderRow(termfmt.TableCell{Text: "Host", RowSpan: 2}, termfmt.TableCell{Text: "Memory", ColSpan: 2})
+func (t *TableBuilder) HeaderRow(cells ...TableCell) *TableBuilder {
+	t.headers = append(t.headers, t.headerPlacer.place(cells, len(t.columns)))
+	return t
+}
+
+// Row appends a data row. Like SpanRow, each cell takes the next slot not
+// covered by a cell spanning down from a row above.
+func (t *TableBuilder) Row(cells ...string) *TableBuilder {
+	t.rows = append(t.rows, t.rowPlacer.placeStrings(cells))
+	return t
+}
+
+// Rows appends several data rows
+func (t *TableBuilder) Rows(rows ...[]string) *TableBuilder {
+	for _, row := range rows {
+		t.Row(row...)
+	}
+
+	return t
+}
+
+// SpanRow appends a data row of cells that may span several columns or
+// rows. Like cells of an HTML table, each cell takes the next slot not
+// covered by a cell spanning down from a row above. Row spans end at rows
+// moved away by SortBy or removed by Filter.
+func (t *TableBuilder) SpanRow(cells ...TableCell) *TableBuilder {
+	t.rows = append(t.rows, t.rowPlacer.place(cells, len(t.columns)))
+	return t
+}
+
+// Section appends a section header, a bold row spanning every column.
+// SortBy sorts rows within their section and GroupBy replaces sections
+// with its groups.
+func (t *TableBuilder) Section(title string) *TableBuilder {
+	t.rowPlacer = spanPlacer{}
+	t.rows = append(t.rows, tableRow{cells: []string{title}, kind: rowGroup})
+
+	return t
+}
+
+// Footer appends a footer row, drawn in bold below a separator, such as a
+// row of totals
+func (t *TableBuilder) Footer(cells ...string) *TableBuilder {
+	t.footers = append(t.footers, cells)
+	return t
+}
+
+// Border sets the border style, overriding TerminalOptions.Border
+func (t *TableBuilder) Border(border BorderStyle) *TableBuilder {
+	t.border = &border
+	return t
+}
+
+// Frame enables or disables the top and bottom borders
+func (t *TableBuilder) Frame(v bool) *TableBuilder {
+	t.frame = v
+	return t
+}
+
+// RowSeparators enables or disables separator lines between data rows
+func (t *TableBuilder) RowSeparators(v bool) *TableBuilder {
+	t.rowSeparators = v
+	return t
+}
+
+// HeaderStyle sets the style of header cells. By default the theme's
+// TableHeader style is used, or bold text in the accent color when the theme
+// leaves it empty.
+func (t *TableBuilder) HeaderStyle(style Style) *TableBuilder {
+	t.headerStyle = style
+	return t
+}
+
+// Zebra sets a style applied to every second data row, such as a background color
+func (t *TableBuilder) Zebra(style Style) *TableBuilder {
+	t.zebra = style
+	return t
+}
+
+// Render draws the table within ctx.Width
+func (t *TableBuilder) Render(ctx RenderContext) string {
+	if len(t.columns) == 0 {
+		return ""
+	}
+
+	opts := ctx.options()
+	if t.border != nil {
+		opts.Border = t.border
+	}
+
+	theme := themeOf(opts)
+
+	headerStyle := t.headerStyle
+	if headerStyle.IsZero() {
+		headerStyle = theme.TableHeader
+	}
+
+	if headerStyle.IsZero() {
+		headerStyle = NewStyle().Bold(true).Foreground(Color(theme.Accent))
+	}
+
+	layout := newTableLayout(t.columns, t.headers, t.process(), plainRows(t.footers), opts)
+	layout.frame = t.frame
+	layout.rowSeparators = t.rowSeparators
+	layout.headerStyle = headerStyle
+	layout.footerStyle = NewStyle().Bold(true)
+	layout.subtotalStyle = NewStyle().Italic(true)
+	layout.zebra = t.zebra
+
+	return layout.render()
+}
+
+// String renders the table with DefaultOptions
+func (t *TableBuilder) String() string {
+	return Render(t, defaultOptions())
+}
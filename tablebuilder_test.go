@@ -0,0 +1,82 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableBuilderFrameAndFooter(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(NewTable("Service", "CPU").
+		Columns(TableColumn{Header: "Service"}, TableColumn{Header: "CPU", Align: AlignRight}).
+		Row("api", "45").
+		Row("db", "5").
+		Footer("total", "50").
+		RowSeparators(true).
+		Border(RoundedBorder()), opts)
+
+	want := strings.Join([]string{
+		"╭─────────┬─────╮",
+		"│ Service │ CPU │",
+		"├─────────┼─────┤",
+		"│ api     │  45 │",
+		"├─────────┼─────┤",
+		"│ db      │   5 │",
+		"├─────────┼─────┤",
+		"│ total   │  50 │",
+		"╰─────────┴─────╯",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("table =\n%s\nwant\n%s", table, want)
+	}
+}
+
+func TestTableBuilderStyles(t *testing.T) {
+	opts := colorOptions()
+	zebra := NewStyle().Background("blue")
+
+	table := Render(NewTable("Name").Rows([]string{"a"}, []string{"b"}, []string{"c"}).Zebra(zebra), opts)
+	lines := strings.Split(table, "\n")
+
+	header := NewStyle().Bold(true).Foreground(Color(DefaultTheme().Accent)).ColorLevel(ColorLevel16)
+	if !contains(lines[1], header.Render("Name")) {
+		t.Errorf("header not styled with the accent color: %q", lines[1])
+	}
+
+	if contains(lines[3], "\033[44m") || !contains(lines[4], "\033[44m") || contains(lines[5], "\033[44m") {
+		t.Errorf("zebra style not applied to every second row:\n%q", lines)
+	}
+
+	assertUniformWidth(t, "TableBuilder", table)
+}
+
+func TestTableBuilderZebraAroundColoredCells(t *testing.T) {
+	opts := colorOptions()
+	zebra := NewStyle().Background("blue")
+	red := func(cell string) string { return Colorize(cell, "red", opts) }
+
+	table := Render(NewTable("Value").
+		Columns(TableColumn{Header: "Value", Format: red}).
+		Rows([]string{"1"}, []string{"123.5"}).
+		Zebra(zebra), opts)
+	lines := strings.Split(table, "\n")
+
+	if !contains(lines[4], Reset+"\033[44m") {
+		t.Errorf("zebra background not reopened after a colored cell: %q", lines[4])
+	}
+
+	assertUniformWidth(t, "TableBuilder", table)
+}
+
+func TestTableBuilderFrameOff(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	got := Render(NewTable("A").Row("1").Frame(false), opts)
+	if want := TableWithOptions([]string{"A"}, [][]string{{"1"}}, opts); got != want {
+		t.Errorf("unframed builder = %q, want TableWithOptions output %q", got, want)
+	}
+}
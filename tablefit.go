@@ -0,0 +1,253 @@
+package termfmt
+
+import "strings"
+
+// recordGap is the space between the header and the value in a record line
+const recordGap = 2
+
+// fit shrinks the columns to maxWidth, lowest Priority first. While Optional
+// columns are visible, columns are shrunk no further than their header,
+// columns of a higher priority than the next optional column are not shrunk
+// at all, and optional columns are hidden, lowest priority and rightmost
+// first, until the table fits. Without optional columns the rest shrink down
+// to their minimum, wrapping their headers, or the record layout is used if
+// opts.TableRecords is set and the table does not fit without wrapping
+// headers. The record layout gives every value its own line, so it shows the
+// optional columns again.
+func (t *tableLayout) fit(mins []int, maxWidth int) {
+	natural := make([]int, len(t.widths))
+	copy(natural, t.widths)
+
+	headerMins := make([]int, len(mins))
+	for i, col := range t.columns {
+		headerMins[i] = max(mins[i], min(StringWidth(col.Header), natural[i]))
+	}
+
+	hidden := make([]bool, len(t.columns))
+	stepMins := make([]int, len(mins))
+
+	for i := t.nextOptional(hidden); i >= 0; i = t.nextOptional(hidden) {
+		for j, col := range t.columns {
+			stepMins[j] = headerMins[j]
+			if col.Priority > t.columns[i].Priority {
+				stepMins[j] = natural[j]
+			}
+		}
+
+		if fitColumns(t.widths, stepMins, t.columns, hidden, maxWidth) {
+			t.removeColumns(hidden, maxWidth)
+			return
+		}
+
+		copy(t.widths, natural)
+
+		hidden[i] = true
+	}
+
+	if t.opts.TableRecords {
+		mins = headerMins
+	}
+
+	if !fitColumns(t.widths, mins, t.columns, hidden, maxWidth) && t.opts.TableRecords {
+		copy(t.widths, natural)
+		t.records = true
+
+		return
+	}
+
+	t.removeColumns(hidden, maxWidth)
+}
+
+// fitColumns shrinks columns one cell at a time until the table fits in
+// maxWidth cells, reporting false when every visible column has reached its
+// minimum first. The widest column of the lowest priority shrinks first.
+func fitColumns(widths, mins []int, columns []TableColumn, hidden []bool, maxWidth int) bool {
+	total := 1 // Left border
+	for i, width := range widths {
+		if !hidden[i] {
+			total += width + tableRowPadding + 1
+		}
+	}
+
+	for total > maxWidth {
+		next := -1
+
+		for i, width := range widths {
+			if hidden[i] || width <= mins[i] {
+				continue
+			}
+
+			if next < 0 || shrinksBefore(columns[i].Priority, width, columns[next].Priority, widths[next]) {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			return false
+		}
+
+		widths[next]--
+		total--
+	}
+
+	return true
+}
+
+// shrinksBefore reports whether a column of the given priority and width
+// is shrunk before another
+func shrinksBefore(priority, width, otherPriority, otherWidth int) bool {
+	if priority != otherPriority {
+		return priority < otherPriority
+	}
+
+	return width > otherWidth
+}
+
+// nextOptional returns the optional column to hide next, or -1 when none is
+// left. The last visible column is never hidden.
+func (t *tableLayout) nextOptional(hidden []bool) int {
+	next, visible := -1, 0
+
+	for i, col := range t.columns {
+		if hidden[i] {
+			continue
+		}
+
+		visible++
+
+		if col.Optional && (next < 0 || col.Priority <= t.columns[next].Priority) {
+			next = i
+		}
+	}
+
+	if visible <= 1 {
+		return -1
+	}
+
+	return next
+}
+
+// removeColumns drops the hidden columns and their cells, then widens the
+// columns under spanning cells that now cover fewer columns, as far as
+// maxWidth allows
+func (t *tableLayout) removeColumns(hidden []bool, maxWidth int) {
+	kept := make([]int, 0, len(hidden))
+
+	for i, h := range hidden {
+		if !h {
+			kept = append(kept, i)
+		}
+	}
+
+	if len(kept) == len(hidden) {
+		return
+	}
+
+	columns := make([]TableColumn, len(kept))
+	widths := make([]int, len(kept))
+
+	for j, i := range kept {
+		columns[j], widths[j] = t.columns[i], t.widths[i]
+	}
+
+	t.columns, t.widths, t.kept = columns, widths, kept
+
+	for _, rows := range [][]tableRow{t.header, t.rows, t.footers} {
+		for r, row := range rows {
+			rows[r] = row.keep(kept)
+		}
+	}
+
+	room := maxWidth - t.spanWidth(0, len(widths)) - tableRowPadding - 2 //nolint:mnd // outer borders
+	_, grid := t.grid()
+	t.eachCell(grid, func(cell *spanCell) {
+		room = t.widenSpan(cell, room)
+	})
+}
+
+// keepCells returns the cells of row at the kept indexes
+func keepCells(row []string, kept []int) []string {
+	cells := make([]string, 0, len(kept))
+
+	for _, i := range kept {
+		if i < len(row) {
+			cells = append(cells, row[i])
+		}
+	}
+
+	return cells
+}
+
+// renderRecords draws every row as a block of header and value lines, for
+// terminals too narrow for the table
+func (t *tableLayout) renderRecords() string {
+	keyWidth, shortest := 0, -1
+	for _, col := range t.columns {
+		width := StringWidth(col.Header)
+		keyWidth = max(keyWidth, width)
+
+		if shortest < 0 || width < shortest {
+			shortest = width
+		}
+	}
+
+	// Headers take at most half the width, but never less than the shortest
+	// header or minColumnWidth so that narrow terminals still show them
+	keyWidth = min(keyWidth, max(t.opts.Width/2, shortest, minColumnWidth)) //nolint:mnd // half the width
+	valueWidth := max(t.opts.Width-keyWidth-recordGap, minColumnWidth)
+
+	blocks := make([]string, 0, len(t.rows)+len(t.footers))
+
+	for _, row := range t.rows {
+		switch row.kind {
+		case rowGroup:
+			blocks = append(blocks, t.footerStyle.RenderWithOptions(Truncate(cellAt(row.cells, 0), t.opts.Width), t.opts))
+		case rowSubtotal:
+			blocks = append(blocks, t.record(row.cells, t.subtotalStyle, keyWidth, valueWidth))
+		case rowData:
+			blocks = append(blocks, t.record(row.cells, Style{}, keyWidth, valueWidth))
+		}
+	}
+
+	for _, row := range t.footers {
+		blocks = append(blocks, t.record(row.cells, t.footerStyle, keyWidth, valueWidth))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// record draws one row as a line per column, wrapping or truncating values
+// to valueWidth
+func (t *tableLayout) record(row []string, valueStyle Style, keyWidth, valueWidth int) string {
+	gap := strings.Repeat(" ", recordGap)
+	indent := strings.Repeat(" ", keyWidth)
+
+	lines := make([]string, 0, len(t.columns))
+
+	for i, col := range t.columns {
+		header := Truncate(col.Header, keyWidth)
+		key := t.headerCellStyle(i).RenderWithOptions(header, t.opts) + strings.Repeat(" ", keyWidth-StringWidth(header))
+
+		value := strings.TrimSpace(cellAt(row, i))
+
+		var values []string
+		if col.Truncate {
+			values = strings.Split(value, "\n")
+			for j, line := range values {
+				values[j] = Truncate(line, valueWidth)
+			}
+		} else {
+			values = wrapLines(value, valueWidth)
+		}
+
+		for j, line := range values {
+			if j > 0 {
+				key = indent
+			}
+
+			lines = append(lines, strings.TrimRight(key+gap+valueStyle.RenderWithOptions(line, t.opts), " "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,155 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableFitPriority(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 30
+
+	columns := []TableColumn{
+		{Header: "Name", Priority: 1},
+		{Header: "Description"},
+	}
+	rows := [][]string{{"payments-service", "handles card payments and refunds"}}
+
+	table := TableWithColumns(columns, rows, opts)
+	lines := strings.Split(table, "\n")
+
+	if !contains(lines[3], "payments-service") {
+		t.Errorf("high priority column was shrunk: %q", lines[3])
+	}
+
+	for _, line := range lines {
+		if StringWidth(line) > opts.Width {
+			t.Errorf("line wider than %d: %q", opts.Width, line)
+		}
+	}
+
+	assertUniformWidth(t, "TableWithColumns", table)
+}
+
+func TestTableFitHidesOptionalColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 24
+
+	columns := []TableColumn{
+		{Header: "Service", MinWidth: 10},
+		{Header: "Region", MinWidth: 6, Optional: true, Priority: 1},
+		{Header: "Notes", MinWidth: 8, Optional: true},
+	}
+	rows := [][]string{{"api", "eu-west", "primary"}}
+
+	table := TableWithColumns(columns, rows, opts)
+
+	if contains(table, "Notes") || !contains(table, "Region") || !contains(table, "eu-west") {
+		t.Errorf("expected only the lowest priority optional column hidden:\n%s", table)
+	}
+
+	opts.Width = 16
+	table = TableWithColumns(columns, rows, opts)
+
+	if contains(table, "Region") || !contains(table, "Service") || !contains(table, "api") {
+		t.Errorf("expected every optional column hidden:\n%s", table)
+	}
+
+	opts.Width = 80
+	if table = TableWithColumns(columns, rows, opts); !contains(table, "Notes") {
+		t.Errorf("optional column hidden although the table fits:\n%s", table)
+	}
+}
+
+func TestTableFitHidesOptionalBeforeWrappingHeaders(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 24
+
+	columns := []TableColumn{
+		{Header: "Hostname"},
+		{Header: "Status"},
+		{Header: "Zone", Optional: true},
+	}
+
+	table := TableWithColumns(columns, [][]string{{"web-1", "running", "eu"}}, opts)
+	lines := strings.Split(table, "\n")
+
+	if contains(table, "Zone") || !contains(lines[0], "Hostname") || !contains(lines[0], "Status") {
+		t.Errorf("expected the optional column hidden before headers wrap:\n%s", table)
+	}
+
+	assertUniformWidth(t, "TableWithColumns", table)
+}
+
+func TestTableFitHidesOptionalBeforeShrinkingPriority(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 30
+
+	columns := []TableColumn{
+		{Header: "Name", Priority: 2},
+		{Header: "Description"},
+		{Header: "Extra", Optional: true},
+	}
+	rows := [][]string{{"service-alpha", "handles card payments", "x"}}
+
+	table := TableWithColumns(columns, rows, opts)
+	lines := strings.Split(table, "\n")
+
+	if contains(table, "Extra") || !contains(lines[3], "service-alpha") {
+		t.Errorf("expected the optional column hidden before the high priority column wraps:\n%s", table)
+	}
+
+	assertUniformWidth(t, "TableWithColumns", table)
+}
+
+func TestTableRecordLayout(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 24
+	opts.TableRecords = true
+
+	headers := []string{"Service", "Status", "Description"}
+	rows := [][]string{
+		{"api", "running", "public http endpoint"},
+		{"db", "stopped", ""},
+	}
+
+	table := TableWithOptions(headers, rows, opts)
+	want := strings.Join([]string{
+		"Service      api",
+		"Status       running",
+		"Description  public http",
+		"             endpoint",
+		"",
+		"Service      db",
+		"Status       stopped",
+		"Description",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("record layout =\n%s\nwant\n%s", table, want)
+	}
+
+	opts.Width = 80
+	if table = TableWithOptions(headers, rows, opts); !contains(table, "─") {
+		t.Errorf("record layout used although the table fits:\n%s", table)
+	}
+}
+
+func TestTableRecordLayoutNarrowKeys(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 5
+	opts.TableRecords = true
+
+	table := TableWithOptions([]string{"Name", "Val"}, [][]string{{"a", "1"}}, opts)
+	lines := strings.Split(table, "\n")
+
+	if len(lines) != 2 || lines[0] != "...  a" || lines[1] != "Val  1" {
+		t.Errorf("record keys truncated away at width 5:\n%s", table)
+	}
+}
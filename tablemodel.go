@@ -0,0 +1,591 @@
+package termfmt
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SortKind selects how the cells of a column are compared
+type SortKind int
+
+const (
+	// SortString compares cells as text
+	SortString SortKind = iota
+	// SortNumeric compares the leading number of cells, such as 45 in "45%".
+	// Cells without a number sort after those with one.
+	SortNumeric
+	// SortNatural compares runs of digits by value, so "node2" sorts before "node10"
+	SortNatural
+	// SortSize compares human-readable sizes such as "512MB" and "2.1GB".
+	// Units ending in iB are powers of 1024, units ending in B powers of 1000
+	// and bare K, M, G, T and P powers of 1024.
+	SortSize
+)
+
+// SortKey sorts table rows by one column
+type SortKey struct {
+	Column     int // Index of the column
+	Kind       SortKind
+	Descending bool
+}
+
+// AggregateFunc combines the cells of a column within a group
+type AggregateFunc int
+
+const (
+	// AggregateSum adds up the numbers, or sizes when the units differ
+	AggregateSum AggregateFunc = iota
+	// AggregateAvg averages the numbers
+	AggregateAvg
+	// AggregateMin shows the smallest number, ignoring other cells
+	AggregateMin
+	// AggregateMax shows the largest number, ignoring other cells
+	AggregateMax
+	// AggregateCount counts the rows
+	AggregateCount
+)
+
+// Aggregate computes a subtotal for one column of every group
+type Aggregate struct {
+	Column int // Index of the column
+	Func   AggregateFunc
+}
+
+// aggregatePrecision is the number of extra decimals kept in averages
+const aggregatePrecision = 2
+
+// SortBy sorts the rows by the given keys, the first key taking precedence.
+// The sort is stable, so rows that compare equal keep their order.
+func (t *TableBuilder) SortBy(keys ...SortKey) *TableBuilder {
+	t.sortKeys = keys
+	return t
+}
+
+// Filter keeps only rows for which keep returns true. Several filters must
+// all keep a row. Filters see the cells before TableColumn.Format is applied.
+func (t *TableBuilder) Filter(keep func(row []string) bool) *TableBuilder {
+	t.filters = append(t.filters, keep)
+	return t
+}
+
+// GroupBy groups rows with the same value in column, in order of first
+// appearance after sorting. Each group starts with a header row naming the
+// value and, when aggregates are given, ends with a subtotal row.
+//
+//	table.SortBy(termfmt.SortKey{Column: 1, Kind: termfmt.SortSize, Descending: true}).
+//		GroupBy(0, termfmt.Aggregate{Column: 1, Func: termfmt.AggregateSum})
+func (t *TableBuilder) GroupBy(column int, aggregates ...Aggregate) *TableBuilder {
+	t.grouped = true
+	t.groupBy = column
+	t.aggregates = aggregates
+
+	return t
+}
+
+// process filters, sorts and groups the rows, returning the rows to draw.
+// Rows are sorted within the sections added by Section, and grouping
+// replaces the sections.
+func (t *TableBuilder) process() []tableRow {
+	rows := make([]tableRow, 0, len(t.rows))
+	sections := make([]int, 0, len(t.rows))
+	section := 0
+
+	for _, row := range t.rows {
+		if row.kind == rowGroup {
+			section++
+		} else if !t.keep(row.cells) {
+			continue
+		}
+
+		rows = append(rows, row)
+		sections = append(sections, section)
+	}
+
+	if len(t.sortKeys) > 0 {
+		sort.Stable(sectionSorter{rows: rows, sections: sections, keys: t.sortKeys})
+	}
+
+	if !t.grouped {
+		return rows
+	}
+
+	return t.group(rows)
+}
+
+// sectionSorter sorts rows by keys without moving them between sections
+type sectionSorter struct {
+	rows     []tableRow
+	sections []int
+	keys     []SortKey
+}
+
+func (s sectionSorter) Len() int { return len(s.rows) }
+
+func (s sectionSorter) Less(i, j int) bool {
+	if s.sections[i] != s.sections[j] {
+		return s.sections[i] < s.sections[j]
+	}
+
+	// Section headers stay first in their section
+	if s.rows[i].kind == rowGroup || s.rows[j].kind == rowGroup {
+		return s.rows[i].kind == rowGroup && s.rows[j].kind != rowGroup
+	}
+
+	return lessRow(s.rows[i].cells, s.rows[j].cells, s.keys)
+}
+
+func (s sectionSorter) Swap(i, j int) {
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.sections[i], s.sections[j] = s.sections[j], s.sections[i]
+}
+
+// keep reports whether every filter keeps row
+func (t *TableBuilder) keep(row []string) bool {
+	for _, keep := range t.filters {
+		if !keep(row) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// group inserts group header and subtotal rows, dropping section headers
+func (t *TableBuilder) group(rows []tableRow) []tableRow {
+	var (
+		order  []string
+		groups = make(map[string][][]string)
+	)
+
+	for _, row := range rows {
+		if row.kind == rowGroup {
+			continue
+		}
+
+		key := cellAt(row.cells, t.groupBy)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+
+		groups[key] = append(groups[key], row.cells)
+	}
+
+	label := ""
+	if t.groupBy < len(t.columns) {
+		label = t.columns[t.groupBy].Header
+	}
+
+	out := make([]tableRow, 0, len(rows)+len(order)*2) //nolint:mnd // header and subtotal per group
+
+	for _, key := range order {
+		header := key
+		if label != "" {
+			header = label + ": " + key
+		}
+
+		out = append(out, tableRow{cells: []string{header}, kind: rowGroup})
+		out = append(out, plainRows(groups[key])...)
+
+		if len(t.aggregates) > 0 {
+			out = append(out, tableRow{cells: t.subtotal(groups[key]), kind: rowSubtotal})
+		}
+	}
+
+	return out
+}
+
+// subtotal returns the aggregate row for the rows of a group, labelled in
+// the first column without an aggregate
+func (t *TableBuilder) subtotal(rows [][]string) []string {
+	row := make([]string, len(t.columns))
+	used := make([]bool, len(t.columns))
+
+	for _, agg := range t.aggregates {
+		if agg.Column < 0 || agg.Column >= len(row) {
+			continue
+		}
+
+		value := aggregate(rows, agg)
+		if format := t.columns[agg.Column].Format; format != nil && agg.Func != AggregateCount {
+			value = format(value)
+		}
+
+		row[agg.Column] = value
+		used[agg.Column] = true
+	}
+
+	for i := range row {
+		if !used[i] {
+			row[i] = "Subtotal"
+			break
+		}
+	}
+
+	return row
+}
+
+// cellAt returns cell i of row, or "" when the row is short
+func cellAt(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+
+	return row[i]
+}
+
+// lessRow compares two rows by keys
+func lessRow(a, b []string, keys []SortKey) bool {
+	for _, key := range keys {
+		if c := compareCells(cellAt(a, key.Column), cellAt(b, key.Column), key.Kind, key.Descending); c != 0 {
+			return c < 0
+		}
+	}
+
+	return false
+}
+
+// compareCells compares two cells, returning -1, 0 or 1. Descending reverses
+// the order, except that cells a SortNumeric or SortSize column cannot parse
+// still sort last.
+func compareCells(a, b string, kind SortKind, descending bool) int {
+	a, b = StripANSI(a), StripANSI(b)
+
+	switch kind {
+	case SortNumeric:
+		return compareParsed(a, b, func(s string) (float64, bool) {
+			n, _, _, ok := parseNumber(s)
+			return n, ok
+		}, descending)
+	case SortSize:
+		return compareParsed(a, b, parseSize, descending)
+	case SortNatural:
+		return reverseIf(compareNatural(a, b), descending)
+	case SortString:
+	}
+
+	return reverseIf(strings.Compare(a, b), descending)
+}
+
+// reverseIf negates the comparison c when reverse is set
+func reverseIf(c int, reverse bool) int {
+	if reverse {
+		return -c
+	}
+
+	return c
+}
+
+// compareParsed compares the values parse extracts from a and b, in reverse
+// when descending. Cells that cannot be parsed sort after the others, by text,
+// in either order.
+func compareParsed(a, b string, parse func(string) (float64, bool), descending bool) int {
+	x, okA := parse(a)
+	y, okB := parse(b)
+
+	switch {
+	case okA && okB:
+		switch {
+		case x < y:
+			return reverseIf(-1, descending)
+		case x > y:
+			return reverseIf(1, descending)
+		}
+
+		return 0
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareNatural compares a and b treating runs of digits as numbers
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		chunkA, restA := naturalChunk(a)
+		chunkB, restB := naturalChunk(b)
+
+		if isDigits(chunkA) && isDigits(chunkB) {
+			x, y := strings.TrimLeft(chunkA, "0"), strings.TrimLeft(chunkB, "0")
+			if len(x) != len(y) {
+				return compareInts(len(x), len(y))
+			}
+
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(chunkA, chunkB); c != 0 {
+			return c
+		}
+
+		a, b = restA, restB
+	}
+
+	return compareInts(len(a), len(b))
+}
+
+// compareInts returns -1, 0 or 1
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// naturalChunk splits off the leading run of digits or non-digits
+func naturalChunk(s string) (chunk, rest string) {
+	digit := unicode.IsDigit(rune(s[0]))
+
+	i := 1
+	for i < len(s) && unicode.IsDigit(rune(s[i])) == digit {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// parseNumber parses the leading number of s, ignoring thousands separators.
+// It returns the number, its count of decimals and the text after it.
+func parseNumber(s string) (value float64, decimals int, unit string, ok bool) {
+	s = strings.TrimSpace(s)
+
+	end := 0
+	for end < len(s) && strings.IndexByte("+-0123456789.,", s[end]) >= 0 {
+		end++
+	}
+
+	number := strings.ReplaceAll(s[:end], ",", "")
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, 0, s, false
+	}
+
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		decimals = len(number) - dot - 1
+	}
+
+	return value, decimals, strings.TrimSpace(s[end:]), true
+}
+
+// sizeUnit is the multiplier of a size unit and whether it is a binary one
+type sizeUnit struct {
+	multiplier float64
+	binary     bool
+}
+
+// sizeUnits holds every size unit keyed by upper case name. Single letters
+// are binary, as in "4K" from ls -h.
+var sizeUnits = map[string]sizeUnit{ //nolint:gochecknoglobals // read-only lookup table used while sorting
+	"": {1, false}, "B": {1, false},
+	"K": {1 << 10, true}, "KIB": {1 << 10, true}, "KB": {1e3, false},
+	"M": {1 << 20, true}, "MIB": {1 << 20, true}, "MB": {1e6, false},
+	"G": {1 << 30, true}, "GIB": {1 << 30, true}, "GB": {1e9, false},
+	"T": {1 << 40, true}, "TIB": {1 << 40, true}, "TB": {1e12, false},
+	"P": {1 << 50, true}, "PIB": {1 << 50, true}, "PB": {1e15, false},
+}
+
+// parseSize parses a human-readable size such as "2.1GB" into bytes
+func parseSize(s string) (float64, bool) {
+	value, _, unit, ok := parseNumber(s)
+	if !ok {
+		return 0, false
+	}
+
+	size, ok := sizeUnits[strings.ToUpper(unit)]
+
+	return value * size.multiplier, ok
+}
+
+// isBinarySize reports whether cells are sizes in binary units, not counting
+// those in bytes
+func isBinarySize(cells []string) bool {
+	binary := false
+
+	for _, cell := range cells {
+		_, _, unit, _ := parseNumber(cell)
+
+		size := sizeUnits[strings.ToUpper(unit)]
+		if size.multiplier == 1 {
+			continue
+		}
+
+		if !size.binary {
+			return false
+		}
+
+		binary = true
+	}
+
+	return binary
+}
+
+// formatSize formats a number of bytes with the largest fitting unit, binary
+// units such as "KiB" when binary is set and SI units otherwise
+func formatSize(bytes float64, binary bool) string {
+	units, step := []string{"B", "KB", "MB", "GB", "TB", "PB"}, 1000.0 //nolint:mnd // SI step
+	if binary {
+		units, step = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}, 1024 //nolint:mnd // IEC step
+	}
+
+	i := 0
+	for math.Abs(bytes) >= step && i < len(units)-1 {
+		bytes /= step
+		i++
+	}
+
+	return strconv.FormatFloat(roundTo(bytes, 1), 'f', -1, 64) + units[i]
+}
+
+// roundTo rounds v to the given number of decimals
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals)) //nolint:mnd // decimal digits
+	return math.Round(v*scale) / scale
+}
+
+// aggregate computes agg over the cells of rows
+func aggregate(rows [][]string, agg Aggregate) string {
+	if agg.Func == AggregateCount {
+		return strconv.Itoa(len(rows))
+	}
+
+	cells := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if cell := StripANSI(cellAt(row, agg.Column)); strings.TrimSpace(cell) != "" {
+			cells = append(cells, cell)
+		}
+	}
+
+	if len(cells) == 0 {
+		return ""
+	}
+
+	switch agg.Func {
+	case AggregateMin, AggregateMax:
+		return extremeCell(cells, agg.Func == AggregateMax)
+	case AggregateSum, AggregateAvg:
+		return sumCells(cells, agg.Func == AggregateAvg)
+	case AggregateCount:
+	}
+
+	return ""
+}
+
+// extremeCell returns the smallest or largest cell, ignoring cells that are
+// not numbers, and "" when none is. Sizes are compared when every number is
+// a size and numbers otherwise.
+func extremeCell(cells []string, largest bool) string {
+	numbers := make([]string, 0, len(cells))
+
+	for _, cell := range cells {
+		if _, _, _, ok := parseNumber(cell); ok {
+			numbers = append(numbers, cell)
+		}
+	}
+
+	if len(numbers) == 0 {
+		return ""
+	}
+
+	kind := SortNumeric
+	if allParse(numbers, parseSize) {
+		kind = SortSize
+	}
+
+	best := numbers[0]
+	for _, cell := range numbers[1:] {
+		c := compareCells(cell, best, kind, false)
+		if (largest && c > 0) || (!largest && c < 0) {
+			best = cell
+		}
+	}
+
+	return best
+}
+
+// sumCells adds up or averages the numbers in cells, ignoring cells that
+// are not numbers. The unit is kept when all numbers share it; sizes with
+// different units are summed in bytes.
+func sumCells(cells []string, average bool) string {
+	var (
+		total    float64
+		decimals int
+		unit     string
+	)
+
+	numbers := make([]string, 0, len(cells))
+	sameUnit := true
+
+	for _, cell := range cells {
+		value, d, u, ok := parseNumber(cell)
+		if !ok {
+			continue
+		}
+
+		if len(numbers) == 0 {
+			unit = u
+		} else if u != unit {
+			sameUnit = false
+		}
+
+		numbers = append(numbers, cell)
+		total += value
+		decimals = max(decimals, d)
+	}
+
+	if len(numbers) == 0 {
+		return ""
+	}
+
+	if !sameUnit && allParse(numbers, parseSize) {
+		var bytes float64
+
+		for _, cell := range numbers {
+			size, _ := parseSize(cell)
+			bytes += size
+		}
+
+		if average {
+			bytes /= float64(len(numbers))
+		}
+
+		return formatSize(bytes, isBinarySize(numbers))
+	}
+
+	if average {
+		total /= float64(len(numbers))
+		decimals += aggregatePrecision
+	}
+
+	result := strconv.FormatFloat(roundTo(total, decimals), 'f', -1, 64)
+	if sameUnit {
+		result += unit
+	}
+
+	return result
+}
+
+// allParse reports whether parse accepts every cell
+func allParse(cells []string, parse func(string) (float64, bool)) bool {
+	for _, cell := range cells {
+		if _, ok := parse(cell); !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,229 @@
+package termfmt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableSortKinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  SortKind
+		cells []string
+		want  []string
+	}{
+		{"string", SortString, []string{"b", "a10", "a2"}, []string{"a10", "a2", "b"}},
+		{"numeric", SortNumeric, []string{"45%", "5%", "n/a", "1,200"}, []string{"5%", "45%", "1,200", "n/a"}},
+		{"natural", SortNatural, []string{"node10", "node2", "node1"}, []string{"node1", "node2", "node10"}},
+		{"size", SortSize, []string{"2GB", "512MB", "1.5GiB", "900K"}, []string{"900K", "512MB", "1.5GiB", "2GB"}},
+	}
+
+	for _, tt := range tests {
+		rows := make([][]string, len(tt.cells))
+		for i, cell := range tt.cells {
+			rows[i] = []string{cell}
+		}
+
+		table := NewTable("Value").Rows(rows...).SortBy(SortKey{Column: 0, Kind: tt.kind})
+		sorted := table.process()
+
+		got := make([]string, len(sorted))
+		for i, row := range sorted {
+			got[i] = row.cells[0]
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: sorted = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTableSortDescendingKeepsUnparsedLast(t *testing.T) {
+	tests := []struct {
+		kind  SortKind
+		cells []string
+		want  []string
+	}{
+		{SortNumeric, []string{"1", "N/A", "3"}, []string{"3", "1", "N/A"}},
+		{SortSize, []string{"N/A", "512MB", "2GB"}, []string{"2GB", "512MB", "N/A"}},
+	}
+
+	for _, tt := range tests {
+		rows := make([][]string, len(tt.cells))
+		for i, cell := range tt.cells {
+			rows[i] = []string{cell}
+		}
+
+		sorted := NewTable("Value").Rows(rows...).SortBy(SortKey{Column: 0, Kind: tt.kind, Descending: true}).process()
+
+		got := make([]string, len(sorted))
+		for i, row := range sorted {
+			got[i] = row.cells[0]
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("kind %d descending: sorted = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestTableSortStableAndDescending(t *testing.T) {
+	table := NewTable("Team", "Name").
+		Row("b", "1").Row("a", "2").Row("b", "3").Row("a", "4").
+		SortBy(SortKey{Column: 0, Descending: true})
+
+	rows := table.process()
+
+	got := make([]string, len(rows))
+	for i, row := range rows {
+		if row.kind != rowData {
+			t.Errorf("row %d kind = %d, want data rows only without grouping", i, row.kind)
+		}
+
+		got[i] = row.cells[1]
+	}
+
+	if want := []string{"1", "3", "2", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestTableFilter(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(NewTable("Service", "Status").
+		Row("api", "up").Row("db", "down").Row("cache", "up").
+		Filter(func(row []string) bool { return row[1] == "up" }).
+		Filter(func(row []string) bool { return row[0] != "cache" }), opts)
+
+	if !contains(table, "api") || contains(table, "db") || contains(table, "cache") {
+		t.Errorf("filters not applied:\n%s", table)
+	}
+}
+
+func TestTableGroupBySubtotals(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(NewTable("Region", "Service", "Memory", "CPU").
+		Columns(
+			TableColumn{Header: "Region"},
+			TableColumn{Header: "Service"},
+			TableColumn{Header: "Memory", Align: AlignRight},
+			TableColumn{Header: "CPU", Align: AlignRight, Format: func(c string) string { return c + "%" }},
+		).
+		Row("eu", "api", "512MB", "40").
+		Row("us", "api", "1GB", "20").
+		Row("eu", "db", "1.5GB", "10").
+		GroupBy(0,
+			Aggregate{Column: 2, Func: AggregateSum},
+			Aggregate{Column: 3, Func: AggregateAvg},
+		), opts)
+
+	want := strings.Join([]string{
+		"┌──────────┬─────────┬────────┬─────┐",
+		"│ Region   │ Service │ Memory │ CPU │",
+		"├──────────┴─────────┴────────┴─────┤",
+		"│ Region: eu                        │",
+		"├──────────┬─────────┬────────┬─────┤",
+		"│ eu       │ api     │  512MB │ 40% │",
+		"│ eu       │ db      │  1.5GB │ 10% │",
+		"│ Subtotal │         │    2GB │ 25% │",
+		"├──────────┴─────────┴────────┴─────┤",
+		"│ Region: us                        │",
+		"├──────────┬─────────┬────────┬─────┤",
+		"│ us       │ api     │    1GB │ 20% │",
+		"│ Subtotal │         │    1GB │ 20% │",
+		"└──────────┴─────────┴────────┴─────┘",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("table =\n%s\nwant\n%s", table, want)
+	}
+}
+
+func TestTableAggregates(t *testing.T) {
+	rows := [][]string{{"3.5"}, {"12"}, {"7.25"}, {""}}
+
+	tests := []struct {
+		fn   AggregateFunc
+		want string
+	}{
+		{AggregateSum, "22.75"},
+		{AggregateAvg, "7.5833"},
+		{AggregateMin, "3.5"},
+		{AggregateMax, "12"},
+		{AggregateCount, "4"},
+	}
+
+	for _, tt := range tests {
+		if got := aggregate(rows, Aggregate{Column: 0, Func: tt.fn}); got != tt.want {
+			t.Errorf("aggregate(%d) = %q, want %q", tt.fn, got, tt.want)
+		}
+	}
+
+	if got := aggregate([][]string{{"2KiB"}, {"1KiB"}}, Aggregate{Func: AggregateSum}); got != "3KiB" {
+		t.Errorf("sum of KiB = %q, want 3KiB", got)
+	}
+
+	if got := aggregate([][]string{{"512KiB"}, {"1.5MiB"}}, Aggregate{Func: AggregateSum}); got != "2MiB" {
+		t.Errorf("sum of KiB and MiB = %q, want 2MiB", got)
+	}
+
+	if got := aggregate([][]string{{"500KB"}, {"1.5MB"}}, Aggregate{Func: AggregateSum}); got != "2MB" {
+		t.Errorf("sum of KB and MB = %q, want 2MB", got)
+	}
+}
+
+func TestTableAggregatesSkipNonNumbers(t *testing.T) {
+	rows := [][]string{{"n/a"}, {"10ms"}, {"-"}, {"20ms"}}
+
+	if got := aggregate(rows, Aggregate{Func: AggregateSum}); got != "30ms" {
+		t.Errorf("sum after a non-number = %q, want 30ms", got)
+	}
+
+	if got := aggregate(rows, Aggregate{Func: AggregateAvg}); got != "15ms" {
+		t.Errorf("average with non-numbers = %q, want 15ms", got)
+	}
+
+	if got := aggregate([][]string{{"n/a"}, {"1KiB"}, {"3KiB"}}, Aggregate{Func: AggregateAvg}); got != "2KiB" {
+		t.Errorf("average size with a non-number = %q, want 2KiB", got)
+	}
+
+	if got := aggregate([][]string{{"n/a"}}, Aggregate{Func: AggregateSum}); got != "" {
+		t.Errorf("sum without numbers = %q, want empty", got)
+	}
+}
+
+func TestTableExtremesSkipNonNumbers(t *testing.T) {
+	tests := []struct {
+		fn   AggregateFunc
+		rows [][]string
+		want string
+	}{
+		{AggregateMax, [][]string{{"10ms"}, {"n/a"}, {"30ms"}}, "30ms"},
+		{AggregateMin, [][]string{{"n/a"}, {"30ms"}, {"10ms"}}, "10ms"},
+		{AggregateMax, [][]string{{"1GB"}, {"-"}, {"512MB"}}, "1GB"},
+		{AggregateMin, [][]string{{"1GB"}, {"-"}, {"512MB"}}, "512MB"},
+		{AggregateMax, [][]string{{"n/a"}, {"-"}}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := aggregate(tt.rows, Aggregate{Func: tt.fn}); got != tt.want {
+			t.Errorf("aggregate %d of %v = %q, want %q", tt.fn, tt.rows, got, tt.want)
+		}
+	}
+
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(NewTable("Team", "Latency").
+		Row("a", "10ms").Row("a", "n/a").Row("a", "30ms").
+		GroupBy(0, Aggregate{Column: 1, Func: AggregateMax}), opts)
+
+	if !contains(table, "30ms") || strings.Count(table, "n/a") != 1 {
+		t.Errorf("max subtotal picked a non-number:\n%s", table)
+	}
+}
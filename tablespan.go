@@ -0,0 +1,472 @@
+package termfmt
+
+import "math"
+
+// TableCell is a table cell that can span several columns or rows, see
+// TableBuilder.SpanRow and TableBuilder.HeaderRow
+type TableCell struct {
+	Text    string
+	ColSpan int // Columns covered, 0 counts as 1, at most those left in the row
+	RowSpan int // Rows covered, 0 counts as 1
+}
+
+// cellSpan is the number of columns and rows a cell covers. The zero value
+// marks a slot covered by another cell.
+type cellSpan struct {
+	cols, rows int
+}
+
+// span returns the span of cell c
+func (r tableRow) span(c int) cellSpan {
+	if c < len(r.spans) {
+		return r.spans[c]
+	}
+
+	return cellSpan{cols: 1, rows: 1}
+}
+
+// covered reports whether the cols slots from c are covered by a cell
+// spanning down from an earlier row
+func (r tableRow) covered(c, cols int) bool {
+	for i := c; i < c+cols; i++ {
+		if i >= len(r.spans) || r.spans[i] != (cellSpan{}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// spanPlacer places cells into the slots of successive rows, leaving slots
+// covered by cells spanning down from earlier rows
+type spanPlacer struct {
+	pending []int // Further rows each slot is covered for
+}
+
+// place returns a row of cells filling the free slots from the left. Cells
+// span no further than the last of columns, unless they start beyond it.
+func (p *spanPlacer) place(cells []TableCell, columns int) tableRow {
+	var row tableRow
+
+	c := 0
+
+	for _, cell := range cells {
+		for p.isCovered(c) {
+			row.cells = append(row.cells, "")
+			row.spans = append(row.spans, cellSpan{})
+			c++
+		}
+
+		span := cellSpan{cols: max(min(cell.ColSpan, columns-c), 1), rows: max(cell.RowSpan, 1)}
+
+		row.cells = append(row.cells, cell.Text)
+		row.spans = append(row.spans, span)
+
+		for i := 1; i < span.cols; i++ {
+			row.cells = append(row.cells, "")
+			row.spans = append(row.spans, cellSpan{})
+		}
+
+		for i := c; i < c+span.cols; i++ {
+			p.cover(i, span.rows)
+		}
+
+		c += span.cols
+	}
+
+	for ; c < len(p.pending); c++ {
+		span := cellSpan{cols: 1, rows: 1}
+		if p.isCovered(c) {
+			span = cellSpan{}
+		}
+
+		row.cells = append(row.cells, "")
+		row.spans = append(row.spans, span)
+	}
+
+	p.next()
+
+	return row
+}
+
+// placeStrings returns a row of plain cells filling the free slots from the
+// left, shifting cells past slots covered from earlier rows
+func (p *spanPlacer) placeStrings(cells []string) tableRow {
+	covered := false
+	for c := range p.pending {
+		covered = covered || p.isCovered(c)
+	}
+
+	if !covered {
+		p.next()
+		return tableRow{cells: cells}
+	}
+
+	spanCells := make([]TableCell, len(cells))
+	for i, cell := range cells {
+		spanCells[i] = TableCell{Text: cell}
+	}
+
+	// Plain cells never span, so any column count will do
+	return p.place(spanCells, len(p.pending)+len(cells))
+}
+
+// isCovered reports whether slot c of the current row is covered
+func (p *spanPlacer) isCovered(c int) bool {
+	return c < len(p.pending) && p.pending[c] > 0
+}
+
+// cover covers slot c for the current row and rows-1 more
+func (p *spanPlacer) cover(c, rows int) {
+	for len(p.pending) <= c {
+		p.pending = append(p.pending, 0)
+	}
+
+	p.pending[c] = rows
+}
+
+// next moves on to the following row
+func (p *spanPlacer) next() {
+	for c := range p.pending {
+		if p.pending[c] > 0 {
+			p.pending[c]--
+		}
+	}
+}
+
+// spanCell is a cell placed in the slots of a table
+type spanCell struct {
+	text       string
+	row, col   int // Top left slot
+	rows, cols int // Slots covered
+	lines      []string
+}
+
+// headerRows returns the extra header rows, a row of column groups when any
+// column has a Group, and the column headers, leaving out those covered by
+// cells spanning down from above
+func (t *tableLayout) headerRows(extra []tableRow) []tableRow {
+	rows := extra[:len(extra):len(extra)]
+	if groups, ok := t.groupRow(); ok {
+		rows = append(rows, groups)
+	}
+
+	names := tableRow{cells: make([]string, len(t.columns)), spans: make([]cellSpan, len(t.columns))}
+	for i, col := range t.columns {
+		names.cells[i] = col.Header
+		names.spans[i] = cellSpan{cols: 1, rows: 1}
+	}
+
+	for r, row := range rows {
+		for c, span := range row.spans {
+			if span.rows <= len(rows)-r {
+				continue
+			}
+
+			for i := c; i < min(c+span.cols, len(t.columns)); i++ {
+				names.cells[i] = ""
+				names.spans[i] = cellSpan{}
+			}
+		}
+	}
+
+	return append(rows, names)
+}
+
+// groupRow returns a header row with a cell spanning each run of columns
+// with the same Group. Columns without a group get their header spanning
+// down over the column header row.
+func (t *tableLayout) groupRow() (tableRow, bool) {
+	grouped := false
+	for _, col := range t.columns {
+		grouped = grouped || col.Group != ""
+	}
+
+	if !grouped {
+		return tableRow{}, false
+	}
+
+	var placer spanPlacer
+
+	cells := make([]TableCell, 0, len(t.columns))
+
+	for i := 0; i < len(t.columns); {
+		col := t.columns[i]
+		if col.Group == "" {
+			cells = append(cells, TableCell{Text: col.Header, RowSpan: 2}) //nolint:mnd // group and header rows
+			i++
+
+			continue
+		}
+
+		span := 1
+		for i+span < len(t.columns) && t.columns[i+span].Group == col.Group {
+			span++
+		}
+
+		cells = append(cells, TableCell{Text: col.Group, ColSpan: span})
+		i += span
+	}
+
+	return placer.place(cells, len(t.columns)), true
+}
+
+// grid places the cells of the header, body and footer rows into slots and
+// returns the rows with, for every slot, the cell covering it. Cells span
+// rows only within their own part of the table.
+func (t *tableLayout) grid() ([]tableRow, [][]*spanCell) {
+	all := make([]tableRow, 0, len(t.header)+len(t.rows)+len(t.footers))
+	all = append(append(append(all, t.header...), t.rows...), t.footers...)
+
+	part := func(r int) int {
+		switch {
+		case r < len(t.header):
+			return 0
+		case r < len(t.header)+len(t.rows):
+			return 1
+		default:
+			return 2 //nolint:mnd // footers
+		}
+	}
+
+	columns := len(t.columns)
+	grid := make([][]*spanCell, len(all))
+
+	for r := range all {
+		grid[r] = make([]*spanCell, columns)
+	}
+
+	for r, row := range all {
+		for c := range columns {
+			if grid[r][c] != nil {
+				continue
+			}
+
+			span := row.span(c)
+			if row.kind == rowGroup {
+				span = cellSpan{cols: columns, rows: 1}
+			}
+
+			cell := &spanCell{text: cellAt(row.cells, c), row: r, col: c, rows: 1, cols: 1}
+
+			for cell.cols < span.cols && c+cell.cols < columns && grid[r][c+cell.cols] == nil {
+				cell.cols++
+			}
+
+			for cell.rows < span.rows && r+cell.rows < len(all) && part(r+cell.rows) == part(r) &&
+				all[r+cell.rows].covered(c, cell.cols) {
+				cell.rows++
+			}
+
+			for i := r; i < r+cell.rows; i++ {
+				for j := c; j < c+cell.cols; j++ {
+					grid[i][j] = cell
+				}
+			}
+		}
+	}
+
+	return all, grid
+}
+
+// eachCell calls fn once for every cell of the grid
+func (t *tableLayout) eachCell(grid [][]*spanCell, fn func(cell *spanCell)) {
+	for r, row := range grid {
+		for c := 0; c < len(row); c += row[c].cols {
+			if row[c].row == r {
+				fn(row[c])
+			}
+		}
+	}
+}
+
+// widenForSpan widens the columns under a cell spanning several columns
+// until the cell fits, one cell at a time to the narrowest column that is
+// below its MaxWidth
+func (t *tableLayout) widenForSpan(cell *spanCell) {
+	if cell.cols > 1 {
+		t.widenSpan(cell, math.MaxInt)
+	}
+}
+
+// widenSpan widens the columns under cell like widenForSpan by at most room
+// cells, returning the room left
+func (t *tableLayout) widenSpan(cell *spanCell, room int) int {
+	for need := cellWidth(cell.text); room > 0 && t.spanWidth(cell.col, cell.cols) < need; room-- {
+		narrowest := -1
+
+		for i := cell.col; i < cell.col+cell.cols; i++ {
+			if limit := t.columns[i].MaxWidth; limit > 0 && t.widths[i] >= limit {
+				continue
+			}
+
+			if narrowest < 0 || t.widths[i] < t.widths[narrowest] {
+				narrowest = i
+			}
+		}
+
+		if narrowest < 0 {
+			return room
+		}
+
+		t.widths[narrowest]++
+	}
+
+	return room
+}
+
+// spanWidth returns the width available to a cell covering cols columns from c
+func (t *tableLayout) spanWidth(c, cols int) int {
+	width := (cols - 1) * (tableRowPadding + 1)
+	for _, w := range t.widths[c : c+cols] {
+		width += w
+	}
+
+	return width
+}
+
+// cellAlign returns the alignment of a cell. Cells spanning several columns
+// are centered in the header and left aligned elsewhere.
+func (t *tableLayout) cellAlign(cell *spanCell) Alignment {
+	switch {
+	case cell.cols == 1:
+		return t.columns[cell.col].Align
+	case cell.row < len(t.header):
+		return AlignCenter
+	default:
+		return AlignLeft
+	}
+}
+
+// rowHeights wraps every cell of the grid to its width and returns the
+// number of lines of each row. Rows under a cell spanning several rows grow
+// when the cell needs more lines than they have together.
+func (t *tableLayout) rowHeights(grid [][]*spanCell) []int {
+	heights := make([]int, len(grid))
+	for r := range heights {
+		heights[r] = 1
+	}
+
+	var tall []*spanCell
+
+	t.eachCell(grid, func(cell *spanCell) {
+		cell.lines = t.cellLines(cell.text, cell.col, t.spanWidth(cell.col, cell.cols))
+
+		if cell.rows == 1 {
+			heights[cell.row] = max(heights[cell.row], len(cell.lines))
+		} else {
+			tall = append(tall, cell)
+		}
+	})
+
+	for _, cell := range tall {
+		have := 0
+		for r := cell.row; r < cell.row+cell.rows; r++ {
+			have += heights[r]
+		}
+
+		if need := len(cell.lines); need > have {
+			heights[cell.row+cell.rows-1] += need - have
+		}
+	}
+
+	return heights
+}
+
+// divides reports whether a cell boundary of row lies at column boundary j,
+// the left edge being 0
+func divides(row []*spanCell, j int) bool {
+	return row != nil && (j == 0 || j == len(row) || row[j-1] != row[j])
+}
+
+// splits reports whether row has more than one cell
+func splits(row []*spanCell) bool {
+	return len(row) > 0 && row[0] != row[len(row)-1]
+}
+
+// junction returns the border rune joining lines in the given directions
+func (t *tableLayout) junction(up, down, left, right, last bool, fill string) string {
+	bs := t.border
+
+	switch {
+	case up && down && left && right:
+		return bs.Middle
+	case down && left && right:
+		return bs.MiddleTop
+	case up && left && right:
+		return bs.MiddleBottom
+	case up && down && right:
+		return bs.MiddleLeft
+	case up && down && left:
+		return bs.MiddleRight
+	case down && right:
+		return bs.TopLeft
+	case down && left:
+		return bs.TopRight
+	case up && right:
+		return bs.BottomLeft
+	case up && left:
+		return bs.BottomRight
+	case (up || down) && last:
+		return bs.Right
+	case up || down:
+		return bs.Left
+	case left || right:
+		return fill
+	default:
+		return " "
+	}
+}
+
+// keep returns the row with only the cells at the kept slots. A cell
+// spanning hidden columns shrinks, and moves to its first kept slot.
+func (r tableRow) keep(kept []int) tableRow {
+	if r.kind == rowGroup {
+		return r
+	}
+
+	if r.spans == nil {
+		return tableRow{cells: keepCells(r.cells, kept), kind: r.kind}
+	}
+
+	isKept := make(map[int]bool, len(kept))
+	for _, i := range kept {
+		isKept[i] = true
+	}
+
+	out := tableRow{kind: r.kind}
+
+	for c := 0; c < max(len(r.cells), len(r.spans)); c++ {
+		span := r.span(c)
+		if span.cols == 0 {
+			// Covered from a row above
+			if isKept[c] {
+				out.cells = append(out.cells, "")
+				out.spans = append(out.spans, cellSpan{})
+			}
+
+			continue
+		}
+
+		cols := 0
+		for i := c; i < c+span.cols; i++ {
+			if isKept[i] {
+				cols++
+			}
+		}
+
+		if cols > 0 {
+			out.cells = append(out.cells, cellAt(r.cells, c))
+			out.spans = append(out.spans, cellSpan{cols: cols, rows: span.rows})
+
+			for range cols - 1 {
+				out.cells = append(out.cells, "")
+				out.spans = append(out.spans, cellSpan{})
+			}
+		}
+
+		c += span.cols - 1
+	}
+
+	return out
+}
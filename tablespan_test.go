@@ -0,0 +1,183 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestTableColumnGroups(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := TableWithColumns([]TableColumn{
+		{Header: "Host"},
+		{Header: "Used", Group: "Memory", Align: AlignRight},
+		{Header: "Free", Group: "Memory", Align: AlignRight},
+		{Header: "Cores", Group: "CPU"},
+	}, [][]string{{"web-1", "12GB", "4GB", "8"}}, opts)
+
+	want := strings.Join([]string{
+		"│ Host  │   Memory    │ CPU   │",
+		"│       ├──────┬──────┼───────┤",
+		"│       │ Used │ Free │ Cores │",
+		"├───────┼──────┼──────┼───────┤",
+		"│ web-1 │ 12GB │  4GB │ 8     │",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("table =\n%s\nwant\n%s", table, want)
+	}
+}
+
+func spanTable() *TableBuilder {
+	return NewTable("Region", "Host", "Load").
+		HeaderRow(TableCell{Text: "Capacity report", ColSpan: 3}).
+		SpanRow(TableCell{Text: "eu", RowSpan: 2}, TableCell{Text: "web-1"}, TableCell{Text: "0.4"}).
+		Row("web-2", "0.9").
+		SpanRow(TableCell{Text: "maintenance window", ColSpan: 2}, TableCell{Text: "-"}).
+		Section("Standby").
+		Row("us", "db-1", "0.1").
+		RowSeparators(true)
+}
+
+func TestTableSpans(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(spanTable(), opts)
+	want := strings.Join([]string{
+		"┌───────────────────────────┐",
+		"│      Capacity report      │",
+		"├──────────┬─────────┬──────┤",
+		"│ Region   │ Host    │ Load │",
+		"├──────────┼─────────┼──────┤",
+		"│ eu       │ web-1   │ 0.4  │",
+		"│          ├─────────┼──────┤",
+		"│          │ web-2   │ 0.9  │",
+		"├──────────┴─────────┼──────┤",
+		"│ maintenance window │ -    │",
+		"├────────────────────┴──────┤",
+		"│ Standby                   │",
+		"├──────────┬─────────┬──────┤",
+		"│ us       │ db-1    │ 0.1  │",
+		"└──────────┴─────────┴──────┘",
+	}, "\n")
+
+	if table != want {
+		t.Errorf("table =\n%s\nwant\n%s", table, want)
+	}
+}
+
+func TestTableSpansASCIIBorder(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(spanTable().Border(ASCIIBorder()), opts)
+
+	for _, r := range table {
+		if r > unicode.MaxASCII {
+			t.Fatalf("non-ASCII rune %q in ASCII table:\n%s", r, table)
+		}
+	}
+
+	if !contains(table, "|          +---------+------+") {
+		t.Errorf("row span junction not drawn with ASCII runes:\n%s", table)
+	}
+
+	assertUniformWidth(t, "ASCII span table", table)
+}
+
+func TestTableSpanShrinksWithHiddenColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 22
+
+	table := TableWithColumns([]TableColumn{
+		{Header: "Host"},
+		{Header: "Used", Group: "Memory"},
+		{Header: "Free", Group: "Memory", Optional: true},
+		{Header: "Cores", Group: "CPU", Optional: true},
+	}, [][]string{{"web-1", "12GB", "4GB", "8"}}, opts)
+
+	if contains(table, "CPU") || contains(table, "Cores") || !contains(table, "Memory") || !contains(table, "Free") {
+		t.Errorf("expected only the CPU group hidden:\n%s", table)
+	}
+
+	assertUniformWidth(t, "hidden span table", table)
+}
+
+func TestSpanLimitedToColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 20
+
+	table := Render(NewTable("Host", "Used", "Free").
+		Row("a", "1", "2").
+		SpanRow(TableCell{Text: "x", ColSpan: 9}), opts)
+
+	lines := strings.Split(table, "\n")
+	if got := strings.Count(lines[1], "│"); got != 4 {
+		t.Errorf("header has %d dividers, want 4 for 3 columns:\n%s", got, table)
+	}
+
+	if got := StringWidth(lines[0]); got > 20 {
+		t.Errorf("table width = %d, want at most 20:\n%s", got, table)
+	}
+
+	assertUniformWidth(t, "span table", table)
+}
+
+func TestRowAfterRowSpanKeepsCells(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	table := Render(NewTable("A", "B", "C").
+		SpanRow(TableCell{Text: "x", RowSpan: 2}, TableCell{Text: "y"}, TableCell{Text: "z"}).
+		Row("p", "q"), opts)
+
+	if lines := strings.Split(table, "\n"); lines[4] != "│   │ p │ q │" {
+		t.Errorf("row under a row span = %q, want its cells shifted past the span:\n%s", lines[4], table)
+	}
+}
+
+func TestSpanPlacer(t *testing.T) {
+	var placer spanPlacer
+
+	first := placer.place([]TableCell{{Text: "a", RowSpan: 2}, {Text: "b", ColSpan: 2}}, 3)
+	second := placer.place([]TableCell{{Text: "c"}, {Text: "d"}}, 3)
+
+	if got := strings.Join(first.cells, ","); got != "a,b," {
+		t.Errorf("first row cells = %q", got)
+	}
+
+	if got := strings.Join(second.cells, ","); got != ",c,d" || !second.covered(0, 1) {
+		t.Errorf("second row cells = %q, spans %v", got, second.spans)
+	}
+
+	third := placer.placeStrings([]string{"e", "f"})
+	if third.spans != nil {
+		t.Errorf("plain row after the span ended has spans %v", third.spans)
+	}
+}
+
+func TestTableSpanWidensAfterHidingColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+	opts.Width = 9
+
+	table := Render(NewTable("A", "B").
+		HeaderRow(TableCell{Text: "Group", ColSpan: 2}).
+		Columns(TableColumn{Header: "A"}, TableColumn{Header: "BBBB", Optional: true}).
+		Row("1", "2"), opts)
+
+	if contains(table, "BBBB") || !contains(table, "Group") {
+		t.Errorf("expected the optional column hidden and the group header on one line:\n%s", table)
+	}
+
+	if got := StringWidth(strings.Split(table, "\n")[0]); got != 9 {
+		t.Errorf("table width = %d, want 9:\n%s", got, table)
+	}
+
+	assertUniformWidth(t, "span table", table)
+}
@@ -0,0 +1,226 @@
+package termfmt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultTableSample is the number of rows a TableWriter buffers to measure
+// column widths
+const DefaultTableSample = 100
+
+// TableWriter writes a table to an io.Writer one row at a time, for row sets
+// too large to hold in memory. Column widths are measured on the first rows,
+// the sample, after which every row is written as soon as it arrives. Later
+// cells wider than their column are wrapped, or truncated for columns with
+// Truncate set. When every column has a MaxWidth the widths are fixed at
+// MaxWidth and nothing is buffered. Rows may have fewer cells than there are
+// columns but not more.
+//
+//	tw := termfmt.NewTableWriter(os.Stdout, columns, opts).RepeatHeader(50)
+//	for entry := range entries {
+//		if err := tw.WriteRow(entry.Time, entry.Level, entry.Message); err != nil {
+//			return err
+//		}
+//	}
+//	return tw.Flush()
+type TableWriter struct {
+	w       io.Writer
+	columns []TableColumn
+	opts    *TerminalOptions
+	sample  int
+	repeat  int
+
+	buffered [][]string
+	layout   *tableLayout // Nil until the widths are known
+	decimals [][2]int     // Integer and fractional width of AlignDecimal columns
+	lastRow  []*spanCell  // Cells of the last row written
+	rows     int          // Rows written since the header
+	err      error
+}
+
+// NewTableWriter returns a writer for a table with the given columns.
+// Headers are written with the first row.
+func NewTableWriter(w io.Writer, columns []TableColumn, opts *TerminalOptions) *TableWriter {
+	if opts == nil {
+		opts = defaultOptions()
+	}
+
+	// Rows are drawn as soon as they arrive, so the record layout is not available
+	copied := *opts
+	copied.TableRecords = false
+
+	return &TableWriter{w: w, columns: columns, opts: &copied, sample: DefaultTableSample}
+}
+
+// SampleSize sets the number of rows buffered to measure column widths.
+// With 0 the widths come from the headers, MinWidth and the first row. It
+// has no effect once the first row is written.
+func (tw *TableWriter) SampleSize(rows int) *TableWriter {
+	tw.sample = max(rows, 0)
+	return tw
+}
+
+// RepeatHeader writes the header again after every n rows, 0 to write it only once
+func (tw *TableWriter) RepeatHeader(n int) *TableWriter {
+	tw.repeat = max(n, 0)
+	return tw
+}
+
+// WriteRow writes a row, or buffers it while the sample is incomplete.
+// It returns the first error of the underlying writer, or an error wrapping
+// ErrRaggedRow without writing the row when it has more cells than columns.
+func (tw *TableWriter) WriteRow(cells ...string) error {
+	if tw.err != nil || len(tw.columns) == 0 {
+		return tw.err
+	}
+
+	if len(cells) > len(tw.columns) {
+		return fmt.Errorf("%w: row has %d cells, want at most %d", ErrRaggedRow, len(cells), len(tw.columns))
+	}
+
+	if tw.layout == nil {
+		tw.buffered = append(tw.buffered, cells)
+		if len(tw.buffered) < tw.sample && !tw.fixed() {
+			return nil
+		}
+
+		return tw.Flush()
+	}
+
+	tw.write(tw.row(cells))
+
+	return tw.err
+}
+
+// Flush measures the column widths from the rows buffered so far if that has
+// not happened yet, writes those rows and flushes the underlying writer when
+// it has a Flush method, such as a bufio.Writer. Until a row is written the
+// widths are left open, so a table without rows writes nothing.
+func (tw *TableWriter) Flush() error {
+	if tw.err != nil || len(tw.columns) == 0 {
+		return tw.err
+	}
+
+	if tw.layout == nil && len(tw.buffered) > 0 {
+		tw.measure()
+
+		var b strings.Builder
+
+		for _, row := range tw.layout.rows {
+			b.WriteString(tw.formattedRow(row))
+		}
+
+		tw.buffered = nil
+		tw.layout.rows = nil
+		tw.write(b.String())
+	}
+
+	if f, ok := tw.w.(interface{ Flush() error }); ok && tw.err == nil {
+		tw.err = f.Flush()
+	}
+
+	return tw.err
+}
+
+// fixed reports whether every column has a MaxWidth, so no sample is needed
+func (tw *TableWriter) fixed() bool {
+	for _, col := range tw.columns {
+		if col.MaxWidth <= 0 {
+			return false
+		}
+	}
+
+	return len(tw.columns) > 0
+}
+
+// measure computes the column widths from the buffered rows, or from the
+// column specs when they are fixed
+func (tw *TableWriter) measure() {
+	columns := tw.columns
+	if tw.fixed() {
+		columns = make([]TableColumn, len(tw.columns))
+		for i, col := range tw.columns {
+			col.MinWidth = max(col.MinWidth, col.MaxWidth)
+			columns[i] = col
+		}
+	}
+
+	tw.layout = newTableLayout(columns, nil, plainRows(tw.buffered), nil, tw.opts)
+
+	tw.decimals = make([][2]int, len(tw.layout.columns))
+	for i, col := range tw.layout.columns {
+		if col.Align == AlignDecimal {
+			whole, frac := decimalWidths(tw.layout.rows, i)
+			tw.decimals[i] = [2]int{whole, frac}
+		}
+	}
+}
+
+// row formats cells like the sample and returns the row's lines
+func (tw *TableWriter) row(cells []string) string {
+	row := formatRow(tw.columns, tableRow{cells: cells})
+	if tw.layout.kept != nil {
+		row = row.keep(tw.layout.kept)
+	}
+
+	rows := []tableRow{row}
+
+	for i, col := range tw.layout.columns {
+		if col.Align == AlignDecimal {
+			alignDecimals(rows, i, tw.decimals[i][0], tw.decimals[i][1])
+		}
+	}
+
+	return tw.formattedRow(row)
+}
+
+// formattedRow returns the lines of a formatted row, preceded by the header
+// when it is the first row or the header is due again
+func (tw *TableWriter) formattedRow(row tableRow) string {
+	t := tw.layout
+
+	var b strings.Builder
+
+	if tw.lastRow == nil || (tw.repeat > 0 && tw.rows >= tw.repeat) {
+		t.rows = nil
+		_, grid := t.grid()
+
+		if tw.lastRow != nil {
+			t.writeRule(&b, tw.lastRow, grid[0], false, t.border.Top)
+		}
+
+		t.writeHeader(&b, grid, t.rowHeights(grid))
+
+		last := grid[len(grid)-1]
+		t.writeRule(&b, last, last, false, t.border.Top)
+
+		tw.rows = 0
+	}
+
+	t.rows = []tableRow{row}
+	_, grid := t.grid()
+	t.writeRow(&b, grid, t.rowHeights(grid), len(t.header), nil, Style{})
+
+	tw.lastRow = grid[len(grid)-1]
+	tw.rows++
+
+	return b.String()
+}
+
+// write writes s to the underlying writer, keeping the first error
+func (tw *TableWriter) write(s string) {
+	if tw.err != nil || s == "" {
+		return
+	}
+
+	if _, err := io.WriteString(tw.w, s); err != nil {
+		tw.err = err
+		return
+	}
+
+	if f, ok := tw.w.(interface{ Flush() error }); ok {
+		tw.err = f.Flush()
+	}
+}
@@ -0,0 +1,152 @@
+package termfmt
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTableWriterMatchesTable(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	columns := []TableColumn{
+		{Header: "Service"},
+		{Header: "Memory", Align: AlignDecimal},
+	}
+	rows := [][]string{{"api", "512MB"}, {"db", "2.1GB"}, {"cache", "64MB"}}
+
+	var out strings.Builder
+
+	tw := NewTableWriter(&out, columns, opts)
+	for _, row := range rows {
+		if err := tw.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow: %v", err)
+		}
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("rows written before the sample was complete:\n%s", out.String())
+	}
+
+	if err := tw.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if want := TableWithColumns(columns, rows, opts) + "\n"; out.String() != want {
+		t.Errorf("streamed table =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestTableWriterSampleAndRepeatHeader(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	var out strings.Builder
+
+	tw := NewTableWriter(&out, []TableColumn{{Header: "ID"}, {Header: "Message", Truncate: true}}, opts).
+		SampleSize(2).
+		RepeatHeader(3)
+
+	_ = tw.WriteRow("1", "short")
+	_ = tw.WriteRow("2", "longer")
+
+	if !contains(out.String(), "longer") {
+		t.Fatalf("sample not written once complete:\n%s", out.String())
+	}
+
+	for _, id := range []string{"3", "4", "5"} {
+		_ = tw.WriteRow(id, "a message wider than the sample")
+		if !contains(out.String(), "│ "+id+"  ") {
+			t.Errorf("row %s not written immediately:\n%s", id, out.String())
+		}
+	}
+
+	want := strings.Join([]string{
+		"│ ID │ Message │",
+		"├────┼─────────┤",
+		"│ 1  │ short   │",
+		"│ 2  │ longer  │",
+		"│ 3  │ a me... │",
+		"├────┼─────────┤",
+		"│ ID │ Message │",
+		"├────┼─────────┤",
+		"│ 4  │ a me... │",
+		"│ 5  │ a me... │",
+		"",
+	}, "\n")
+
+	if out.String() != want {
+		t.Errorf("table =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	assertUniformWidth(t, "TableWriter", strings.TrimSuffix(out.String(), "\n"))
+}
+
+func TestTableWriterFixedColumns(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	buf := &strings.Builder{}
+	w := bufio.NewWriter(buf)
+
+	tw := NewTableWriter(w, []TableColumn{{Header: "Level", MaxWidth: 5}, {Header: "Message", MaxWidth: 10}}, opts)
+	if err := tw.WriteRow("info", "started"); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[2] != "│ info  │ started    │" {
+		t.Errorf("fixed width row not written and flushed at once: %q", lines)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestTableWriterEmptyFlushAndWideRows(t *testing.T) {
+	opts := colorOptions()
+	opts.Color = false
+
+	columns := []TableColumn{{Header: "ID"}, {Header: "Message"}}
+
+	var out strings.Builder
+
+	tw := NewTableWriter(&out, columns, opts)
+	if err := tw.Flush(); err != nil || out.Len() != 0 {
+		t.Fatalf("Flush without rows = %v, wrote %q", err, out.String())
+	}
+
+	if err := tw.WriteRow("1", "a", "extra"); !errors.Is(err, ErrRaggedRow) {
+		t.Errorf("WriteRow with too many cells error = %v, want ErrRaggedRow", err)
+	}
+
+	rows := [][]string{{"1", "a message measured after the empty flush"}}
+	if err := tw.WriteRow(rows[0]...); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+
+	if err := tw.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if want := TableWithColumns(columns, rows, opts) + "\n"; out.String() != want {
+		t.Errorf("streamed table =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestTableWriterError(t *testing.T) {
+	tw := NewTableWriter(failingWriter{}, []TableColumn{{Header: "A", MaxWidth: 3}}, nil)
+
+	if err := tw.WriteRow("x"); err == nil {
+		t.Fatal("expected the writer's error")
+	}
+
+	if err := tw.Flush(); err == nil || err.Error() != "disk full" {
+		t.Errorf("Flush() = %v, want the first error", err)
+	}
+}
@@ -3,13 +3,15 @@ package termfmt
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
 const (
-	// MaxFieldLength is the maximum length for field values before truncation
+	// MaxFieldLength is the maximum display width for field values before truncation
 	MaxFieldLength = 50
 )
 
@@ -19,29 +21,40 @@ type terminalFormatter struct {
 	colorProfile *ColorProfile
 }
 
-// NewTerminal creates a new terminal formatter with optional color support
+// NewTerminal creates a new terminal formatter for standard output, with
+// options detected by Detect. With color set, output is colored only when
+// stdout is a terminal or color is forced by the environment; use
+// NewTerminalFor to format for another writer.
 func NewTerminal(color bool) Formatter {
-	opts := DefaultOptions()
-	opts.Color = color
+	return newTerminalEnv(color, OSEnv())
+}
 
-	return &terminalFormatter{
-		options:      opts,
-		colorProfile: DefaultColorProfile(),
-	}
+// newTerminalEnv implements NewTerminal with an injectable environment
+func newTerminalEnv(color bool, env Env) Formatter {
+	opts := detectEnv(os.Stdout, env)
+	opts.Color = opts.Color && color
+
+	return NewTerminalWithOptions(opts)
 }
 
 // NewTerminalWithOptions creates a new terminal formatter with custom options
 func NewTerminalWithOptions(opts *TerminalOptions) Formatter {
 	if opts == nil {
-		opts = DefaultOptions()
+		opts = defaultOptions()
 	}
 
 	return &terminalFormatter{
 		options:      opts,
-		colorProfile: DefaultColorProfile(),
+		colorProfile: &themeOf(opts).ColorProfile,
 	}
 }
 
+// NewTerminalFor creates a new terminal formatter with options detected from w,
+// so that output written to a pipe or file carries no escape sequences
+func NewTerminalFor(w io.Writer) Formatter {
+	return NewTerminalWithOptions(Detect(w))
+}
+
 // SetColorProfile sets the color profile for the formatter
 func (f *terminalFormatter) SetColorProfile(profile *ColorProfile) {
 	f.colorProfile = profile
@@ -57,8 +70,12 @@ func (f *terminalFormatter) Format(data interface{}) ([]byte, error) {
 
 	// Handle different data types
 	switch v := data.(type) {
+	case Renderable:
+		output.WriteString(v.Render(NewRenderContext(f.options)))
 	case map[string]interface{}:
 		f.formatMap(&output, v, "")
+	case []KeyValue:
+		f.formatEntries(&output, v, "")
 	case []interface{}:
 		f.formatSlice(&output, v)
 	case string:
@@ -107,7 +124,7 @@ func (f *terminalFormatter) formatStruct(output *strings.Builder, data interface
 
 	header := Header(structName, f.options)
 	output.WriteString(header + "\n")
-	output.WriteString(strings.Repeat("─", len(structName)) + "\n\n")
+	output.WriteString(strings.Repeat("─", StringWidth(structName)) + "\n\n")
 
 	// Format fields as tree view
 	items := f.structToTreeItems(v, t)
@@ -131,7 +148,7 @@ func (f *terminalFormatter) structToTreeItems(v reflect.Value, t reflect.Type) [
 		}
 
 		label := fieldType.Name
-		value := f.formatFieldValue(field)
+		value := formatFieldValue(field, f.options)
 
 		item := TreeItem{
 			Label: label,
@@ -155,11 +172,12 @@ func (f *terminalFormatter) structToTreeItems(v reflect.Value, t reflect.Type) [
 	return items
 }
 
-// formatFieldValue formats a field value for display
-func (f *terminalFormatter) formatFieldValue(v reflect.Value) string {
+// formatFieldValue formats a field value for display. It is shared by struct
+// formatting and TableFromStructs.
+func formatFieldValue(v reflect.Value, opts *TerminalOptions) string {
 	switch v.Kind() {
 	case reflect.String:
-		return f.formatStringValue(v)
+		return formatStringValue(v)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -167,56 +185,51 @@ func (f *terminalFormatter) formatFieldValue(v reflect.Value) string {
 	case reflect.Float32, reflect.Float64:
 		return strconv.FormatFloat(v.Float(), 'f', 2, 64)
 	case reflect.Bool:
-		return f.formatBoolValue(v)
+		return formatBoolValue(v, opts)
 	case reflect.Slice, reflect.Array:
 		return fmt.Sprintf("[%d items]", v.Len())
 	case reflect.Map:
 		return fmt.Sprintf("{%d keys}", v.Len())
 	case reflect.Ptr, reflect.Interface:
-		return f.formatPointerValue(v)
+		return formatPointerValue(v, opts)
 	case reflect.Struct:
 		return fmt.Sprintf("{%s}", v.Type().Name())
 	case reflect.Invalid, reflect.Uintptr, reflect.Complex64, reflect.Complex128,
 		reflect.Chan, reflect.Func, reflect.UnsafePointer:
-		return f.formatSpecialValue(v)
+		return formatSpecialValue(v, opts)
 	default:
 		return fmt.Sprintf("<%s>", v.Kind())
 	}
 }
 
 // formatStringValue formats string values with truncation
-func (f *terminalFormatter) formatStringValue(v reflect.Value) string {
-	str := v.String()
-	if len(str) > MaxFieldLength {
-		str = str[:MaxFieldLength-3] + "..."
-	}
-
-	return fmt.Sprintf("%q", str)
+func formatStringValue(v reflect.Value) string {
+	return fmt.Sprintf("%q", Truncate(v.String(), MaxFieldLength))
 }
 
 // formatBoolValue formats boolean values with styling
-func (f *terminalFormatter) formatBoolValue(v reflect.Value) string {
+func formatBoolValue(v reflect.Value, opts *TerminalOptions) string {
 	if v.Bool() {
-		return Success("true", f.options)
+		return Success("true", opts)
 	}
 
-	return Muted("false", f.options)
+	return Muted("false", opts)
 }
 
 // formatPointerValue formats pointer and interface values
-func (f *terminalFormatter) formatPointerValue(v reflect.Value) string {
+func formatPointerValue(v reflect.Value, opts *TerminalOptions) string {
 	if v.IsNil() {
-		return Muted("nil", f.options)
+		return Muted("nil", opts)
 	}
 
-	return f.formatFieldValue(v.Elem())
+	return formatFieldValue(v.Elem(), opts)
 }
 
 // formatSpecialValue formats special types (complex, chan, func, etc.)
-func (f *terminalFormatter) formatSpecialValue(v reflect.Value) string {
+func formatSpecialValue(v reflect.Value, opts *TerminalOptions) string {
 	switch v.Kind() {
 	case reflect.Invalid:
-		return Muted("invalid", f.options)
+		return Muted("invalid", opts)
 	case reflect.Uintptr:
 		return fmt.Sprintf("0x%x", v.Uint())
 	case reflect.Complex64, reflect.Complex128:
@@ -238,30 +251,39 @@ func (f *terminalFormatter) formatSpecialValue(v reflect.Value) string {
 	}
 }
 
-// formatMap formats a map for display
+// formatMap formats a map for display, ordered by the Ordering option
 func (f *terminalFormatter) formatMap(
 	output *strings.Builder,
 	data map[string]interface{},
 	prefix string,
 ) {
-	for key, value := range data {
+	f.formatEntries(output, mapEntries(data), prefix)
+}
+
+// formatEntries formats key and value pairs for display, ordered by the
+// Ordering option
+func (f *terminalFormatter) formatEntries(
+	output *strings.Builder,
+	entries []KeyValue,
+	prefix string,
+) {
+	for _, entry := range orderEntries(entries, f.options.Ordering) {
 		output.WriteString(prefix)
 
-		keyStr := ColorizeWithProfile(key, "accent", f.colorProfile, f.options)
+		keyStr := ColorizeWithProfile(entry.Key, "accent", f.colorProfile, f.options)
 		output.WriteString(keyStr + ": ")
 
-		switch v := value.(type) {
+		switch v := entry.Value.(type) {
 		case map[string]interface{}:
 			output.WriteString("\n")
 			f.formatMap(output, v, prefix+"  ")
+		case []KeyValue:
+			output.WriteString("\n")
+			f.formatEntries(output, v, prefix+"  ")
 		case []interface{}:
 			fmt.Fprintf(output, "[%d items]\n", len(v))
 		case string:
-			if len(v) > MaxFieldLength {
-				v = v[:MaxFieldLength-3] + "..."
-			}
-
-			output.WriteString(fmt.Sprintf("%q", v) + "\n")
+			output.WriteString(fmt.Sprintf("%q", Truncate(v, MaxFieldLength)) + "\n")
 		case nil:
 			output.WriteString(Muted("nil", f.options) + "\n")
 		default:
@@ -297,7 +319,7 @@ func (f *terminalFormatter) formatSlice(output *strings.Builder, data []interfac
 // FormatAsTable formats data as a table (utility function)
 func FormatAsTable(headers []string, rows [][]string, opts *TerminalOptions) string {
 	if opts == nil {
-		opts = DefaultOptions()
+		opts = defaultOptions()
 	}
 
 	return TableWithOptions(headers, rows, opts)
@@ -306,7 +328,7 @@ func FormatAsTable(headers []string, rows [][]string, opts *TerminalOptions) str
 // FormatAsBox formats content in a box (utility function)
 func FormatAsBox(title, content string, opts *TerminalOptions) string {
 	if opts == nil {
-		opts = DefaultOptions()
+		opts = defaultOptions()
 	}
 
 	return BoxWithOptions(title, content, opts)
@@ -315,35 +337,41 @@ func FormatAsBox(title, content string, opts *TerminalOptions) string {
 // FormatAsBarChart formats data as a bar chart (utility function)
 func FormatAsBarChart(data map[string]int, width int, opts *TerminalOptions) string {
 	if opts == nil {
-		opts = DefaultOptions()
+		opts = defaultOptions()
 	}
 
 	return BarChartWithOptions(data, width, opts)
 }
 
-// Summary creates a formatted summary box
+// Summary creates a formatted summary box. Items are ordered by
+// opts.Ordering, by key unless configured otherwise.
 func Summary(title string, items map[string]interface{}, opts *TerminalOptions) string {
+	return SummaryPairs(title, mapEntries(items), opts)
+}
+
+// SummaryPairs creates a formatted summary box from key and value pairs.
+// With the zero Ordering the items keep the order given.
+func SummaryPairs(title string, items []KeyValue, opts *TerminalOptions) string {
 	if opts == nil {
-		opts = DefaultOptions()
+		opts = defaultOptions()
 	}
 
+	items = orderEntries(items, opts.Ordering)
+
 	var content strings.Builder
 
 	maxKeyLen := 0
 
-	// Find max key length for alignment
-	for key := range items {
-		if len(key) > maxKeyLen {
-			maxKeyLen = len(key)
-		}
+	// Find max key width for alignment
+	for _, item := range items {
+		maxKeyLen = max(maxKeyLen, StringWidth(item.Key))
 	}
 
-	for key, value := range items {
-		padding := maxKeyLen - len(key)
-		keyStr := ColorizeWithProfile(key, "info", DefaultColorProfile(), opts)
-		valueStr := fmt.Sprintf("%v", value)
+	for _, item := range items {
+		keyStr := ColorizeWithProfile(item.Key, "info", &themeOf(opts).ColorProfile, opts)
+		valueStr := fmt.Sprintf("%v", item.Value)
 
-		content.WriteString(keyStr + strings.Repeat(" ", padding) + ": " + valueStr + "\n")
+		content.WriteString(padRight(keyStr, maxKeyLen) + ": " + valueStr + "\n")
 	}
 
 	return BoxWithOptions(title, strings.TrimRight(content.String(), "\n"), opts)
@@ -0,0 +1,332 @@
+package termfmt
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultEllipsis marks text removed by Truncate
+	DefaultEllipsis = "..."
+
+	hyperlinkPrefix = "\033]8;"        // OSC 8 hyperlink introducer
+	hyperlinkClose  = "\033]8;;\033\\" // OSC 8 sequence that ends a hyperlink
+)
+
+// EllipsisPosition controls which part of a string Truncate removes
+type EllipsisPosition int
+
+const (
+	// EllipsisEnd keeps the beginning of the string
+	EllipsisEnd EllipsisPosition = iota
+	// EllipsisMiddle keeps the beginning and the end of the string
+	EllipsisMiddle
+	// EllipsisStart keeps the end of the string
+	EllipsisStart
+)
+
+// styleState tracks the SGR attributes and hyperlink active at a point in a string
+type styleState struct {
+	sgr  []string
+	link string
+}
+
+// apply updates the state with an escape sequence
+func (st *styleState) apply(seq string) {
+	if strings.HasPrefix(seq, hyperlinkPrefix) {
+		// OSC 8 ; params ; URI ST - an empty URI closes the link
+		body := strings.TrimRight(strings.TrimPrefix(seq, hyperlinkPrefix), "\033\\\a")
+		if _, uri, ok := strings.Cut(body, ";"); ok && uri != "" {
+			st.link = seq
+		} else {
+			st.link = ""
+		}
+
+		return
+	}
+
+	if len(seq) < 3 || seq[1] != '[' || seq[len(seq)-1] != 'm' {
+		return
+	}
+
+	params := seq[2 : len(seq)-1]
+	if first, _, _ := strings.Cut(params, ";"); first == "" || first == "0" {
+		st.sgr = st.sgr[:0]
+
+		if !strings.Contains(params, ";") {
+			return
+		}
+	}
+
+	st.sgr = append(st.sgr, seq)
+}
+
+// open returns the escape sequences that re-establish the state
+func (st *styleState) open() string {
+	return strings.Join(st.sgr, "") + st.link
+}
+
+// close returns the escape sequences that terminate the state
+func (st *styleState) close() string {
+	var b strings.Builder
+
+	if len(st.sgr) > 0 {
+		b.WriteString(Reset)
+	}
+
+	if st.link != "" {
+		b.WriteString(hyperlinkClose)
+	}
+
+	return b.String()
+}
+
+// segments splits s into escape sequences and grapheme clusters
+func segments(s string) []segment {
+	segs := make([]segment, 0, len(s))
+
+	for len(s) > 0 {
+		seg := nextSegment(s)
+		segs = append(segs, seg)
+		s = s[len(seg.text):]
+	}
+
+	return segs
+}
+
+// Truncate shortens s to at most width display cells, replacing the removed
+// text at the end with DefaultEllipsis. Escape sequences are never split and
+// any style active at the cut is closed.
+func Truncate(s string, width int) string {
+	return TruncateEllipsis(s, width, DefaultEllipsis, EllipsisEnd)
+}
+
+// TruncateEllipsis shortens s to at most width display cells, replacing the
+// removed text with ellipsis at the given position. Styles active on either
+// side of the ellipsis are closed before it and re-opened after it.
+func TruncateEllipsis(s string, width int, ellipsis string, position EllipsisPosition) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if StringWidth(s) <= width {
+		return s
+	}
+
+	available := width - StringWidth(ellipsis)
+	if available < 0 {
+		return truncateHead(segments(ellipsis), width)
+	}
+
+	segs := segments(s)
+
+	switch position {
+	case EllipsisStart:
+		return ellipsis + truncateTail(segs, available)
+	case EllipsisMiddle:
+		tailWidth := available / 2 //nolint:mnd // split the budget in half
+		return truncateHead(segs, available-tailWidth) + ellipsis + truncateTail(segs, tailWidth)
+	case EllipsisEnd:
+		return truncateHead(segs, available) + ellipsis
+	default:
+		return truncateHead(segs, available) + ellipsis
+	}
+}
+
+// truncateHead returns the longest prefix of segs that fits in width cells,
+// closing any style that is still active at the cut
+func truncateHead(segs []segment, width int) string {
+	var (
+		b     strings.Builder
+		st    styleState
+		used  int
+		split bool
+	)
+
+	for _, seg := range segs {
+		if seg.escape {
+			st.apply(seg.text)
+			b.WriteString(seg.text)
+
+			continue
+		}
+
+		if used+seg.width > width {
+			split = true
+			break
+		}
+
+		used += seg.width
+		b.WriteString(seg.text)
+	}
+
+	if split {
+		b.WriteString(st.close())
+	}
+
+	return b.String()
+}
+
+// truncateTail returns the longest suffix of segs that fits in width cells,
+// re-opening the style that was active where the suffix starts
+func truncateTail(segs []segment, width int) string {
+	start := len(segs)
+	used := 0
+
+	for start > 0 {
+		seg := segs[start-1]
+		if !seg.escape && used+seg.width > width {
+			break
+		}
+
+		used += seg.width
+		start--
+	}
+
+	var st styleState
+
+	for _, seg := range segs[:start] {
+		if seg.escape {
+			st.apply(seg.text)
+		}
+	}
+
+	var b strings.Builder
+
+	b.WriteString(st.open())
+
+	for _, seg := range segs[start:] {
+		b.WriteString(seg.text)
+	}
+
+	return b.String()
+}
+
+// WrapText word-wraps s so that no line is wider than width display cells.
+// Existing line breaks are kept, words longer than width are broken, and
+// styles active at a line break are closed at the end of the line and
+// re-opened at the start of the next one.
+func WrapText(s string, width int) string {
+	return strings.Join(wrapLines(s, width), "\n")
+}
+
+// wrapLines word-wraps s and returns the resulting lines
+func wrapLines(s string, width int) []string {
+	if width <= 0 {
+		return strings.Split(s, "\n")
+	}
+
+	w := &wrapper{width: width}
+
+	for _, paragraph := range strings.Split(s, "\n") {
+		w.wrapParagraph(paragraph)
+	}
+
+	return w.lines
+}
+
+// wrapper accumulates word-wrapped lines while tracking active styles
+type wrapper struct {
+	width int
+	lines []string
+	state styleState
+
+	line      strings.Builder
+	lineWidth int
+	word      []segment
+	wordWidth int
+	spaces    int
+	wrapped   bool
+}
+
+// wrapParagraph wraps a single line of input
+func (w *wrapper) wrapParagraph(paragraph string) {
+	w.line.WriteString(w.state.open())
+	w.wrapped = false
+
+	for _, seg := range segments(paragraph) {
+		if seg.text == " " {
+			w.flushWord()
+			w.spaces++
+
+			continue
+		}
+
+		w.word = append(w.word, seg)
+		w.wordWidth += seg.width
+	}
+
+	w.flushWord()
+	w.spaces = 0
+	w.endLine()
+}
+
+// flushWord places the pending word on the current line, starting a new
+// line first if the word does not fit
+func (w *wrapper) flushWord() {
+	if len(w.word) == 0 {
+		return
+	}
+
+	if w.lineWidth > 0 && w.lineWidth+w.spaces+w.wordWidth > w.width {
+		w.breakLine()
+	}
+
+	if w.spaces > 0 && (w.lineWidth > 0 || !w.wrapped) {
+		pad := min(w.spaces, w.width-w.lineWidth)
+		w.line.WriteString(strings.Repeat(" ", pad))
+		w.lineWidth += pad
+	}
+
+	for _, seg := range w.word {
+		if seg.escape {
+			w.state.apply(seg.text)
+			w.line.WriteString(seg.text)
+
+			continue
+		}
+
+		if w.lineWidth > 0 && w.lineWidth+seg.width > w.width {
+			w.breakLine()
+		}
+
+		w.line.WriteString(seg.text)
+		w.lineWidth += seg.width
+	}
+
+	w.word = w.word[:0]
+	w.wordWidth = 0
+	w.spaces = 0
+}
+
+// breakLine ends the current line and starts a continuation line
+func (w *wrapper) breakLine() {
+	w.endLine()
+	w.line.WriteString(w.state.open())
+	w.wrapped = true
+}
+
+// endLine closes active styles and stores the current line
+func (w *wrapper) endLine() {
+	w.line.WriteString(w.state.close())
+	w.lines = append(w.lines, w.line.String())
+	w.line.Reset()
+	w.lineWidth = 0
+}
+
+// Indent prefixes every non-empty line of s with n spaces
+func Indent(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+
+	for i, line := range lines {
+		if StripANSI(line) != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
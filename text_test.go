@@ -0,0 +1,79 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		position EllipsisPosition
+		want     string
+	}{
+		{"fits", "short", 10, EllipsisEnd, "short"},
+		{"end", "hello world", 8, EllipsisEnd, "hello..."},
+		{"start", "hello world", 8, EllipsisStart, "...world"},
+		{"middle", "hello world", 8, EllipsisMiddle, "hel...ld"},
+		{"wide runes", "日本語テキスト", 7, EllipsisEnd, "日本..."},
+		{"ellipsis wider than width", "hello world", 2, EllipsisEnd, ".."},
+		{"styled end", Red + "hello world" + Reset, 8, EllipsisEnd, Red + "hello" + Reset + "..."},
+		{"styled start", Red + "hello world" + Reset, 8, EllipsisStart, "..." + Red + "world" + Reset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateEllipsis(tt.input, tt.width, DefaultEllipsis, tt.position)
+			if got != tt.want {
+				t.Errorf("TruncateEllipsis(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+
+			if StringWidth(got) > tt.width {
+				t.Errorf("TruncateEllipsis(%q, %d) is %d cells wide", tt.input, tt.width, StringWidth(got))
+			}
+		})
+	}
+}
+
+func TestTruncateKeepsRunesIntact(t *testing.T) {
+	got := Truncate(strings.Repeat("é", 60), MaxFieldLength)
+	if !strings.HasSuffix(got, DefaultEllipsis) || StringWidth(got) != MaxFieldLength {
+		t.Errorf("Truncate() = %q, want %d cells ending in an ellipsis", got, MaxFieldLength)
+	}
+
+	if !strings.HasPrefix(got, "éé") || strings.ContainsRune(got, '�') {
+		t.Errorf("Truncate() split a rune: %q", got)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := WrapText("the quick brown fox jumps over the lazy dog", 10)
+	want := "the quick\nbrown fox\njumps over\nthe lazy\ndog"
+
+	if got != want {
+		t.Errorf("WrapText() = %q, want %q", got, want)
+	}
+
+	long := WrapText("abcdefghij", 4)
+	if long != "abcd\nefgh\nij" {
+		t.Errorf("WrapText() long word = %q", long)
+	}
+}
+
+func TestWrapTextReopensStyles(t *testing.T) {
+	got := WrapText(Red+"one two"+Reset+" three", 5)
+	want := Red + "one" + Reset + "\n" + Red + "two" + Reset + "\nthree"
+
+	if got != want {
+		t.Errorf("WrapText() = %q, want %q", got, want)
+	}
+}
+
+func TestIndent(t *testing.T) {
+	got := Indent("a\n\nb", 2)
+	if got != "  a\n\n  b" {
+		t.Errorf("Indent() = %q", got)
+	}
+}
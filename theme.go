@@ -0,0 +1,287 @@
+package termfmt
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Theme extends ColorProfile with styles for every component element.
+// The zero value of a Style leaves the element unstyled.
+type Theme struct {
+	ColorProfile
+
+	Name string
+
+	BoxBorder     Style // Box border lines
+	BoxTitle      Style // Box titles
+	TableBorder   Style // Table borders and separators
+	TableHeader   Style // Table header cells
+	TreeGuide     Style // Tree view branch guides
+	BarFill       Style // Filled part of bar charts and confidence bars
+	BarEmpty      Style // Empty part of bar charts and confidence bars
+	ProgressFill  Style // Filled part of progress bars
+	ProgressEmpty Style // Empty part of progress bars
+	Header        Style // Text rendered by Header
+	Subtitle      Style // Text rendered by Subtitle
+	MutedText     Style // Text rendered by Muted
+}
+
+// Clone returns a copy of the theme that can be modified independently
+func (t *Theme) Clone() *Theme {
+	c := *t
+	return &c
+}
+
+// colorFields returns the theme's semantic colors keyed by their name in theme files
+func (t *Theme) colorFields() map[string]*string {
+	return map[string]*string{
+		"error":   &t.Error,
+		"warning": &t.Warning,
+		"info":    &t.Info,
+		"success": &t.Success,
+		"accent":  &t.Accent,
+		"muted":   &t.Muted,
+	}
+}
+
+// styleFields returns the theme's element styles keyed by their name in theme files
+func (t *Theme) styleFields() map[string]*Style {
+	return map[string]*Style{
+		"box_border":     &t.BoxBorder,
+		"box_title":      &t.BoxTitle,
+		"table_border":   &t.TableBorder,
+		"table_header":   &t.TableHeader,
+		"tree_guide":     &t.TreeGuide,
+		"bar_fill":       &t.BarFill,
+		"bar_empty":      &t.BarEmpty,
+		"progress_fill":  &t.ProgressFill,
+		"progress_empty": &t.ProgressEmpty,
+		"header":         &t.Header,
+		"subtitle":       &t.Subtitle,
+		"muted_text":     &t.MutedText,
+	}
+}
+
+// DefaultTheme returns the theme used when TerminalOptions.Theme is nil
+func DefaultTheme() *Theme {
+	return &Theme{
+		ColorProfile: *DefaultColorProfile(),
+		Name:         "default",
+		Header:       NewStyle().Bold(true).Foreground(Cyan),
+		Subtitle:     NewStyle().Bold(true),
+		MutedText:    NewStyle().Faint(true),
+	}
+}
+
+// DarkTheme returns a theme with bright colors for dark backgrounds
+func DarkTheme() *Theme {
+	return &Theme{
+		ColorProfile:  *HighContrastColorProfile(),
+		Name:          "dark",
+		BoxBorder:     NewStyle().Foreground(BrightBlack),
+		BoxTitle:      NewStyle().Bold(true).Foreground(BrightWhite),
+		TableBorder:   NewStyle().Foreground(BrightBlack),
+		TableHeader:   NewStyle().Bold(true).Foreground(BrightCyan),
+		TreeGuide:     NewStyle().Foreground(BrightBlack),
+		BarFill:       NewStyle().Foreground(BrightCyan),
+		BarEmpty:      NewStyle().Foreground(BrightBlack),
+		ProgressFill:  NewStyle().Foreground(BrightGreen),
+		ProgressEmpty: NewStyle().Foreground(BrightBlack),
+		Header:        NewStyle().Bold(true).Foreground(BrightCyan),
+		Subtitle:      NewStyle().Bold(true).Foreground(BrightWhite),
+		MutedText:     NewStyle().Foreground(BrightBlack),
+	}
+}
+
+// LightTheme returns a theme with darker colors for light backgrounds
+func LightTheme() *Theme {
+	return &Theme{
+		ColorProfile: ColorProfile{
+			Error:   "160",
+			Warning: "130",
+			Info:    "25",
+			Success: "28",
+			Accent:  "30",
+			Muted:   "244",
+		},
+		Name:          "light",
+		BoxBorder:     NewStyle().Foreground(ANSI256(248)),
+		BoxTitle:      NewStyle().Bold(true).Foreground(ANSI256(236)),
+		TableBorder:   NewStyle().Foreground(ANSI256(248)),
+		TableHeader:   NewStyle().Bold(true).Foreground(ANSI256(25)),
+		TreeGuide:     NewStyle().Foreground(ANSI256(248)),
+		BarFill:       NewStyle().Foreground(ANSI256(25)),
+		BarEmpty:      NewStyle().Foreground(ANSI256(252)),
+		ProgressFill:  NewStyle().Foreground(ANSI256(28)),
+		ProgressEmpty: NewStyle().Foreground(ANSI256(252)),
+		Header:        NewStyle().Bold(true).Foreground(ANSI256(25)),
+		Subtitle:      NewStyle().Bold(true).Foreground(ANSI256(236)),
+		MutedText:     NewStyle().Foreground(ANSI256(244)),
+	}
+}
+
+// SolarizedTheme returns a theme using the Solarized accent colors
+func SolarizedTheme() *Theme {
+	const (
+		base01  = "#586e75"
+		base1   = "#93a1a1"
+		yellow  = "#b58900"
+		orange  = "#cb4b16"
+		red     = "#dc322f"
+		violet  = "#6c71c4"
+		blue    = "#268bd2"
+		cyan    = "#2aa198"
+		green   = "#859900"
+		magenta = "#d33682"
+	)
+
+	return &Theme{
+		ColorProfile: ColorProfile{
+			Error:   red,
+			Warning: yellow,
+			Info:    blue,
+			Success: green,
+			Accent:  cyan,
+			Muted:   base01,
+		},
+		Name:          "solarized",
+		BoxBorder:     NewStyle().Foreground(base01),
+		BoxTitle:      NewStyle().Bold(true).Foreground(orange),
+		TableBorder:   NewStyle().Foreground(base01),
+		TableHeader:   NewStyle().Bold(true).Foreground(blue),
+		TreeGuide:     NewStyle().Foreground(base01),
+		BarFill:       NewStyle().Foreground(violet),
+		BarEmpty:      NewStyle().Foreground(base01),
+		ProgressFill:  NewStyle().Foreground(green),
+		ProgressEmpty: NewStyle().Foreground(base01),
+		Header:        NewStyle().Bold(true).Foreground(magenta),
+		Subtitle:      NewStyle().Bold(true).Foreground(base1),
+		MutedText:     NewStyle().Foreground(base01),
+	}
+}
+
+// MonochromeTheme returns a theme that uses text attributes only
+func MonochromeTheme() *Theme {
+	return &Theme{
+		Name:        "monochrome",
+		BoxTitle:    NewStyle().Bold(true),
+		TableHeader: NewStyle().Bold(true).Underline(true),
+		BarEmpty:    NewStyle().Faint(true),
+		Header:      NewStyle().Bold(true).Underline(true),
+		Subtitle:    NewStyle().Bold(true),
+		MutedText:   NewStyle().Faint(true),
+	}
+}
+
+// DeuteranopiaTheme returns the default theme with DeuteranopiaColorProfile
+func DeuteranopiaTheme() *Theme {
+	return colorBlindTheme("deuteranopia", DeuteranopiaColorProfile())
+}
+
+// ProtanopiaTheme returns the default theme with ProtanopiaColorProfile
+func ProtanopiaTheme() *Theme {
+	return colorBlindTheme("protanopia", ProtanopiaColorProfile())
+}
+
+// TritanopiaTheme returns the default theme with TritanopiaColorProfile
+func TritanopiaTheme() *Theme {
+	return colorBlindTheme("tritanopia", TritanopiaColorProfile())
+}
+
+// colorBlindTheme builds a theme around a color-blind-safe profile
+func colorBlindTheme(name string, profile *ColorProfile) *Theme {
+	theme := DefaultTheme()
+	theme.Name = name
+	theme.ColorProfile = *profile
+	theme.Header = NewStyle().Bold(true).Foreground(Color(profile.Info))
+	theme.TableHeader = NewStyle().Bold(true)
+
+	return theme
+}
+
+// builtinThemes returns constructors for the built-in themes keyed by name
+func builtinThemes() map[string]func() *Theme {
+	return map[string]func() *Theme{
+		"default":      DefaultTheme,
+		"dark":         DarkTheme,
+		"light":        LightTheme,
+		"solarized":    SolarizedTheme,
+		"monochrome":   MonochromeTheme,
+		"deuteranopia": DeuteranopiaTheme,
+		"protanopia":   ProtanopiaTheme,
+		"tritanopia":   TritanopiaTheme,
+	}
+}
+
+// ThemeByName returns a new instance of the built-in theme with the given name
+func ThemeByName(name string) (*Theme, error) {
+	theme, ok := builtinThemes()[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q", name)
+	}
+
+	return theme(), nil
+}
+
+// ThemeRegistry holds named themes. It is safe for concurrent use.
+type ThemeRegistry struct {
+	mu     sync.RWMutex
+	themes map[string]*Theme
+}
+
+// NewThemeRegistry returns a registry containing the built-in themes
+func NewThemeRegistry() *ThemeRegistry {
+	r := &ThemeRegistry{themes: make(map[string]*Theme)}
+
+	for name, theme := range builtinThemes() {
+		r.themes[name] = theme()
+	}
+
+	return r
+}
+
+// Register adds a theme under its Name, replacing any theme with the same name
+func (r *ThemeRegistry) Register(theme *Theme) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.themes[theme.Name] = theme.Clone()
+}
+
+// Get returns a copy of the named theme
+func (r *ThemeRegistry) Get(name string) (*Theme, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	theme, ok := r.themes[name]
+	if !ok {
+		return nil, false
+	}
+
+	return theme.Clone(), true
+}
+
+// Names returns the names of all registered themes in sorted order
+func (r *ThemeRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.themes))
+	for name := range r.themes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// themeOf returns the theme configured in opts, or the default theme
+func themeOf(opts *TerminalOptions) *Theme {
+	if opts == nil || opts.Theme == nil {
+		return DefaultTheme()
+	}
+
+	return opts.Theme
+}
@@ -0,0 +1,127 @@
+package termfmt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuiltinThemes(t *testing.T) {
+	registry := NewThemeRegistry()
+
+	for _, name := range []string{"default", "dark", "light", "solarized", "monochrome"} {
+		theme, ok := registry.Get(name)
+		if !ok {
+			t.Errorf("registry is missing built-in theme %q", name)
+			continue
+		}
+
+		if theme.Name != name {
+			t.Errorf("theme %q has Name %q", name, theme.Name)
+		}
+	}
+
+	if _, err := ThemeByName("neon"); err == nil {
+		t.Error("ThemeByName() accepted an unknown theme")
+	}
+}
+
+func TestThemeRegistryRegister(t *testing.T) {
+	registry := NewThemeRegistry()
+
+	custom := DarkTheme()
+	custom.Name = "custom"
+	registry.Register(custom)
+
+	custom.Accent = "#000000"
+
+	got, ok := registry.Get("custom")
+	if !ok || got.Accent == "#000000" {
+		t.Error("Register() did not store an independent copy of the theme")
+	}
+}
+
+func TestParseThemeTOML(t *testing.T) {
+	doc := `
+# Brand theme
+name = "brand"
+base = "monochrome"
+
+[colors]
+accent = "#ff8800" # orange
+muted = 244
+
+[styles]
+table_header = "bold underline #ff8800"
+`
+
+	theme, err := ParseTheme([]byte(doc), ThemeTOML)
+	if err != nil {
+		t.Fatalf("ParseTheme() error = %v", err)
+	}
+
+	if theme.Name != "brand" || theme.Accent != "#ff8800" || theme.Muted != "244" {
+		t.Errorf("ParseTheme() = %+v", theme)
+	}
+
+	want := NewStyle().Bold(true).Underline(true).Foreground("#ff8800")
+	if theme.TableHeader != want {
+		t.Errorf("TableHeader = %+v, want %+v", theme.TableHeader, want)
+	}
+
+	if theme.MutedText != MonochromeTheme().MutedText {
+		t.Error("ParseTheme() did not start from the base theme")
+	}
+}
+
+func TestLoadThemeJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	doc := `{"name": "ocean", "colors": {"info": "bright-blue"}, "styles": {"header": "bold cyan"}}`
+
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatalf("LoadTheme() error = %v", err)
+	}
+
+	if theme.Name != "ocean" || theme.Info != "bright-blue" {
+		t.Errorf("LoadTheme() = %+v", theme)
+	}
+}
+
+func TestParseThemeErrors(t *testing.T) {
+	tests := []string{
+		`unknown_key = "red"`,
+		`error = "not-a-color"`,
+		`header = "bold sparkly"`,
+		`base = "neon"`,
+		`accent = #ff8800`,
+	}
+
+	for _, doc := range tests {
+		if _, err := ParseTheme([]byte(doc), ThemeTOML); err == nil {
+			t.Errorf("ParseTheme(%q) succeeded, want error", doc)
+		}
+	}
+}
+
+func TestThemeStylesComponents(t *testing.T) {
+	opts := colorOptions()
+	opts.Theme = MonochromeTheme()
+
+	if got := Header("Title", opts); got != "\033[1;4mTitle\033[0m" {
+		t.Errorf("Header() = %q", got)
+	}
+
+	if got := Error("failed", opts); got != "failed" {
+		t.Errorf("Error() with monochrome theme = %q, want plain text", got)
+	}
+
+	table := TableWithOptions([]string{"Name"}, [][]string{{"api"}}, opts)
+	if !contains(table, "\033[1;4mName\033[0m") {
+		t.Errorf("TableWithOptions() did not style the header:\n%q", table)
+	}
+}
@@ -0,0 +1,242 @@
+package termfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ThemeFormat identifies the syntax of a theme file
+type ThemeFormat int
+
+const (
+	// ThemeJSON is a JSON object of theme keys, optionally grouped in nested objects
+	ThemeJSON ThemeFormat = iota
+	// ThemeTOML is a TOML document of theme keys, optionally grouped in tables
+	ThemeTOML
+)
+
+// LoadTheme reads a theme from a .json or .toml file.
+//
+// Theme files assign colors ("error", "warning", "info", "success", "accent",
+// "muted") and styles ("box_border", "table_header", "header", ...) using the
+// syntax accepted by Color and ParseStyle. The optional "base" key names the
+// built-in theme the file extends, otherwise DefaultTheme is used:
+//
+//	name = "brand"
+//	base = "dark"
+//
+//	[colors]
+//	accent = "#ff8800"
+//
+//	[styles]
+//	table_header = "bold #ff8800"
+func LoadTheme(path string) (*Theme, error) {
+	var format ThemeFormat
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = ThemeJSON
+	case ".toml":
+		format = ThemeTOML
+	default:
+		return nil, fmt.Errorf("unsupported theme file %q: expected .json or .toml", path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // reading a caller-supplied theme file is the purpose
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	theme, err := ParseTheme(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return theme, nil
+}
+
+// ParseTheme parses a theme document in the given format, see LoadTheme
+func ParseTheme(data []byte, format ThemeFormat) (*Theme, error) {
+	var (
+		values map[string]string
+		err    error
+	)
+
+	switch format {
+	case ThemeJSON:
+		values, err = parseThemeJSON(data)
+	case ThemeTOML:
+		values, err = parseThemeTOML(data)
+	default:
+		return nil, fmt.Errorf("unknown theme format %d", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTheme(values)
+}
+
+// buildTheme applies theme file values on top of their base theme
+func buildTheme(values map[string]string) (*Theme, error) {
+	theme := DefaultTheme()
+
+	if base, ok := values["base"]; ok {
+		var err error
+		if theme, err = ThemeByName(base); err != nil {
+			return nil, err
+		}
+	}
+
+	if name, ok := values["name"]; ok {
+		theme.Name = name
+	}
+
+	colors := theme.colorFields()
+	styles := theme.styleFields()
+
+	// Apply keys in sorted order so that errors are reported deterministically
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := values[key]
+
+		if color, ok := colors[key]; ok {
+			if c := Color(value); c != NoColor && !c.valid() {
+				return nil, fmt.Errorf("invalid color %q for %q", value, key)
+			}
+
+			*color = value
+
+			continue
+		}
+
+		if style, ok := styles[key]; ok {
+			parsed, err := ParseStyle(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid style for %q: %w", key, err)
+			}
+
+			*style = parsed
+
+			continue
+		}
+
+		if key != "name" && key != "base" {
+			return nil, fmt.Errorf("unknown theme key %q", key)
+		}
+	}
+
+	return theme, nil
+}
+
+// parseThemeJSON flattens a JSON theme object into key/value pairs
+func parseThemeJSON(data []byte) (map[string]string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid theme JSON: %w", err)
+	}
+
+	values := make(map[string]string)
+
+	return values, flattenThemeJSON(doc, values)
+}
+
+// flattenThemeJSON copies scalar values into values, descending into nested objects
+func flattenThemeJSON(doc map[string]interface{}, values map[string]string) error {
+	for key, value := range doc {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case map[string]interface{}:
+			if err := flattenThemeJSON(v, values); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported value for theme key %q", key)
+		}
+	}
+
+	return nil
+}
+
+// parseThemeTOML parses the subset of TOML used by theme files: key/value
+// pairs with string or integer values, table headers and comments
+func parseThemeTOML(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(stripTOMLComment(raw))
+		if line == "" || (strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")) {
+			// Tables only group keys, every key name is unique
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n+1)
+		}
+
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid string %s", n+1, value)
+			}
+
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			if len(value) < 2 || !strings.HasSuffix(value, "'") { //nolint:mnd // opening and closing quote
+				return nil, fmt.Errorf("line %d: invalid string %s", n+1, value)
+			}
+
+			value = value[1 : len(value)-1]
+		default:
+			if _, err := strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("line %d: unsupported value %s", n+1, value)
+			}
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// stripTOMLComment removes a trailing comment, ignoring '#' inside strings
+func stripTOMLComment(line string) string {
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
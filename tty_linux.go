@@ -0,0 +1,48 @@
+//go:build linux
+
+package termfmt
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors struct winsize from <sys/ioctl.h>
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+// isTerminal reports whether the file descriptor refers to a terminal by
+// requesting its termios settings
+func isTerminal(f fdWriter) bool {
+	var termios syscall.Termios
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		f.Fd(),
+		syscall.TCGETS,
+		uintptr(unsafe.Pointer(&termios)), //nolint:gosec // required by ioctl
+	)
+
+	return errno == 0
+}
+
+// terminalSize queries the window size of the terminal behind f
+func terminalSize(f fdWriter) (cols, rows int, ok bool) {
+	var ws winsize
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		f.Fd(),
+		syscall.TIOCGWINSZ,
+		uintptr(unsafe.Pointer(&ws)), //nolint:gosec // required by ioctl
+	)
+	if errno != 0 || ws.Col == 0 {
+		return 0, 0, false
+	}
+
+	return int(ws.Col), int(ws.Row), true
+}
@@ -0,0 +1,25 @@
+//go:build !linux
+
+package termfmt
+
+import (
+	"os"
+)
+
+// isTerminal reports whether f is a character device. Without ioctl support
+// this is the closest portable approximation of a terminal check.
+func isTerminal(f fdWriter) bool {
+	file, ok := f.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalSize is not available on this platform
+func terminalSize(_ fdWriter) (cols, rows int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,265 @@
+package termfmt
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	escapeByte = 0x1b   // ESC, introduces every ANSI escape sequence
+	bellByte   = 0x07   // BEL, terminates OSC sequences
+	zeroWidthJ = 0x200D // ZERO WIDTH JOINER, glues emoji into a single glyph
+	emojiVS    = 0xFE0F // VARIATION SELECTOR-16, requests emoji presentation
+	textVS     = 0xFE0E // VARIATION SELECTOR-15, requests text presentation
+	wideCells  = 2      // Cells occupied by wide characters and emoji
+)
+
+// segment is the smallest unit of display text: either a complete escape
+// sequence or a complete grapheme cluster
+type segment struct {
+	text   string
+	width  int
+	escape bool
+}
+
+// StringWidth returns the number of terminal cells needed to display s.
+// ANSI escape sequences occupy no cells, grapheme clusters (combining marks,
+// ZWJ emoji sequences, flags, variation selectors) are measured as a single
+// glyph, and East Asian wide characters and emoji occupy two cells.
+func StringWidth(s string) int {
+	width := 0
+
+	for len(s) > 0 {
+		seg := nextSegment(s)
+		width += seg.width
+		s = s[len(seg.text):]
+	}
+
+	return width
+}
+
+// StripANSI removes all ANSI escape sequences from s
+func StripANSI(s string) string {
+	if strings.IndexByte(s, escapeByte) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	for len(s) > 0 {
+		seg := nextSegment(s)
+		if !seg.escape {
+			b.WriteString(seg.text)
+		}
+
+		s = s[len(seg.text):]
+	}
+
+	return b.String()
+}
+
+// RuneWidth returns the number of cells a single rune occupies when it is
+// displayed on its own
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case isZeroWidth(r):
+		return 0
+	case isWide(r):
+		return wideCells
+	default:
+		return 1
+	}
+}
+
+// nextSegment returns the escape sequence or grapheme cluster at the start of s.
+// s must not be empty.
+func nextSegment(s string) segment {
+	if s[0] == escapeByte {
+		return segment{text: s[:escapeLength(s)], escape: true}
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	width := RuneWidth(r)
+	regional := isRegionalIndicator(r)
+	joined := false
+
+	for size < len(s) {
+		next, n := utf8.DecodeRuneInString(s[size:])
+
+		switch {
+		case next == zeroWidthJ:
+			joined = true
+		case joined:
+			// The joined rune renders as part of the preceding glyph
+			joined = false
+		case next == emojiVS:
+			width = wideCells
+		case next == textVS:
+			width = min(width, 1)
+		case regional && isRegionalIndicator(next):
+			// A pair of regional indicators forms a single flag
+			regional = false
+			width = wideCells
+		case isExtending(next):
+		default:
+			return segment{text: s[:size], width: width}
+		}
+
+		size += n
+	}
+
+	return segment{text: s[:size], width: width}
+}
+
+// escapeLength returns the length in bytes of the escape sequence at the
+// start of s. Unterminated sequences extend to the end of s.
+func escapeLength(s string) int {
+	if len(s) < 2 { //nolint:mnd // ESC plus introducer
+		return len(s)
+	}
+
+	switch s[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes followed by a final byte
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM, APC: terminated by BEL or ST (ESC \)
+		for i := 2; i < len(s); i++ {
+			if s[i] == bellByte {
+				return i + 1
+			}
+
+			if s[i] == escapeByte && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2 //nolint:mnd // ESC plus backslash
+			}
+		}
+	default:
+		// Two-byte sequences, optionally with intermediate bytes (e.g. ESC ( B)
+		for i := 1; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] > 0x2f {
+				return i + 1
+			}
+		}
+	}
+
+	return len(s)
+}
+
+// isExtending reports whether r continues the grapheme cluster before it
+func isExtending(r rune) bool {
+	switch {
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Emoji tag sequences
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF: // Variation selectors
+		return true
+	case r >= 0x1160 && r <= 0x11FF: // Hangul medial vowels and final consonants
+		return true
+	default:
+		return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+	}
+}
+
+// isZeroWidth reports whether r occupies no cells on its own
+func isZeroWidth(r rune) bool {
+	if r >= 0x1160 && r <= 0x11FF {
+		return true
+	}
+
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// isRegionalIndicator reports whether r is one half of a flag emoji
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isWide reports whether r is an East Asian wide or fullwidth character or
+// an emoji with default emoji presentation
+//
+//nolint:gocyclo,funlen // flat lookup table
+func isWide(r rune) bool {
+	if r < 0x1100 {
+		return false
+	}
+
+	switch {
+	case r <= 0x115F: // Hangul Jamo initial consonants
+		return true
+	case r == 0x231A, r == 0x231B, r == 0x2329, r == 0x232A:
+		return true
+	case r >= 0x23E9 && r <= 0x23EC, r == 0x23F0, r == 0x23F3:
+		return true
+	case r == 0x25FD, r == 0x25FE, r == 0x2614, r == 0x2615:
+		return true
+	case r >= 0x2648 && r <= 0x2653, r == 0x267F, r == 0x2693, r == 0x26A1:
+		return true
+	case r == 0x26AA, r == 0x26AB, r == 0x26BD, r == 0x26BE, r == 0x26C4, r == 0x26C5:
+		return true
+	case r == 0x26CE, r == 0x26D4, r == 0x26EA, r == 0x26F2, r == 0x26F3:
+		return true
+	case r == 0x26F5, r == 0x26FA, r == 0x26FD, r == 0x2705, r == 0x270A, r == 0x270B:
+		return true
+	case r == 0x2728, r == 0x274C, r == 0x274E, r >= 0x2753 && r <= 0x2755, r == 0x2757:
+		return true
+	case r >= 0x2795 && r <= 0x2797, r == 0x27B0, r == 0x27BF:
+		return true
+	case r == 0x2B1B, r == 0x2B1C, r == 0x2B50, r == 0x2B55:
+		return true
+	case r >= 0x2E80 && r <= 0x303E: // CJK radicals, symbols and punctuation
+		return true
+	case r >= 0x3041 && r <= 0x33FF: // Kana, Bopomofo, CJK compatibility
+		return true
+	case r >= 0x3400 && r <= 0x4DBF: // CJK extension A
+		return true
+	case r >= 0x4E00 && r <= 0x9FFF: // CJK unified ideographs
+		return true
+	case r >= 0xA000 && r <= 0xA4CF: // Yi
+		return true
+	case r >= 0xA960 && r <= 0xA97F, r >= 0xAC00 && r <= 0xD7A3: // Hangul
+		return true
+	case r >= 0xF900 && r <= 0xFAFF: // CJK compatibility ideographs
+		return true
+	case r >= 0xFE10 && r <= 0xFE19, r >= 0xFE30 && r <= 0xFE6F: // Vertical and small forms
+		return true
+	case r >= 0xFF00 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6: // Fullwidth forms
+		return true
+	case r >= 0x16FE0 && r <= 0x16FE4, r >= 0x17000 && r <= 0x18AFF: // Tangut
+		return true
+	case r >= 0x1B000 && r <= 0x1B2FF: // Kana supplement and extensions
+		return true
+	case r == 0x1F004, r == 0x1F0CF, r == 0x1F18E, r >= 0x1F191 && r <= 0x1F19A:
+		return true
+	case r >= 0x1F200 && r <= 0x1F251: // Enclosed ideographic supplement
+		return true
+	case r >= 0x1F300 && r <= 0x1F64F: // Pictographs and emoticons
+		return true
+	case r >= 0x1F680 && r <= 0x1F6FF: // Transport and map symbols
+		return true
+	case r >= 0x1F7E0 && r <= 0x1F7EB: // Colored circles and squares
+		return true
+	case r >= 0x1F90C && r <= 0x1F9FF, r >= 0x1FA70 && r <= 0x1FAFF: // Supplemental pictographs
+		return true
+	case r >= 0x20000 && r <= 0x2FFFD, r >= 0x30000 && r <= 0x3FFFD: // CJK extensions B and beyond
+		return true
+	default:
+		return false
+	}
+}
+
+// padRight pads s with spaces on the right until it is width cells wide
+func padRight(s string, width int) string {
+	if gap := width - StringWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+
+	return s
+}
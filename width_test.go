@@ -0,0 +1,69 @@
+package termfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"ascii", "hello", 5},
+		{"precomposed accent", "caf\u00e9", 4},
+		{"combining accent", "cafe\u0301", 4},
+		{"cjk", "日本語", 6},
+		{"fullwidth", "ＡＢ", 4},
+		{"emoji", "✅", 2},
+		{"emoji with variation selector", "⚠️", 2},
+		{"text variation selector", "⚠\ufe0e", 1},
+		{"zwj sequence", "👨\u200d👩\u200d👧", 2},
+		{"skin tone modifier", "👍🏽", 2},
+		{"flag", "🇹🇷", 2},
+		{"sgr sequence", Red + "red" + Reset, 3},
+		{"hyperlink", "\033]8;;https://example.com\033\\link\033]8;;\033\\", 4},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringWidth(tt.input); got != tt.want {
+				t.Errorf("StringWidth(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	input := Bold + Red + "error" + Reset + ": " + "\033[38;5;208mdisk\033[0m"
+	if got := StripANSI(input); got != "error: disk" {
+		t.Errorf("StripANSI() = %q, want %q", got, "error: disk")
+	}
+}
+
+func TestComponentsAlignWideContent(t *testing.T) {
+	box := Box("概要", "名前: 日本\n"+Red+"colored"+Reset+"\n✅ done")
+	assertUniformWidth(t, "Box", box)
+
+	table := Table([]string{"Name", "Status"}, [][]string{
+		{"café", "✅"},
+		{"東京", Green + "up" + Reset},
+	})
+	assertUniformWidth(t, "Table", table)
+}
+
+// assertUniformWidth checks that every line of a rendered component has the same display width
+func assertUniformWidth(t *testing.T, name, output string) {
+	t.Helper()
+
+	lines := strings.Split(output, "\n")
+	want := StringWidth(lines[0])
+
+	for i, line := range lines {
+		if got := StringWidth(line); got != want {
+			t.Errorf("%s line %d has width %d, want %d:\n%s", name, i, got, want, output)
+		}
+	}
+}